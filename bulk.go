@@ -0,0 +1,214 @@
+package sol
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// Batcher is implemented by statements that can be split into multiple
+// statements of fewer rows, such as an INSERT with many values.
+type Batcher interface {
+	Executable
+	Len() int
+	Batch(i, j int) Executable
+}
+
+var _ Batcher = InsertStmt{}
+
+// rower is implemented by statements with rows of values, such as
+// InsertStmt, whose parameters can be counted row by row
+type rower interface {
+	Rows() ([]string, [][]interface{}, error)
+}
+
+// BatchSize returns the maximum number of rows per batch that will keep
+// each statement within the parameter limit of the given dialect.
+func BatchSize(d dialect.Dialect, stmt Batcher) (int, error) {
+	limit := dialect.MaxParams(d)
+	rows := stmt.Len()
+	if limit == 0 || rows < 2 {
+		return rows, nil
+	}
+
+	perRow, rest, err := countParams(d, stmt)
+	if err != nil {
+		return 0, err
+	}
+	if perRow == 0 {
+		return rows, nil
+	}
+	size := (limit - rest) / perRow
+	if size < 1 {
+		return 0, fmt.Errorf(
+			"sol: a single row exceeds the dialect's limit of %d parameters",
+			limit,
+		)
+	}
+	if size > rows {
+		return rows, nil
+	}
+	return size, nil
+}
+
+// countParams returns the most parameters used by any row of the
+// statement and those used by the rest of the statement, such as an
+// ON CONFLICT clause. Rows may use fewer parameters than others, such
+// as those with DEFAULT values. Statements without rows are estimated
+// by compiling their first one and two rows.
+func countParams(d dialect.Dialect, stmt Batcher) (perRow, rest int, err error) {
+	r, ok := stmt.(rower)
+	if !ok {
+		_, one, err := compile(d, stmt.Batch(0, 1))
+		if err != nil {
+			return 0, 0, err
+		}
+		_, two, err := compile(d, stmt.Batch(0, 2))
+		if err != nil {
+			return 0, 0, err
+		}
+		perRow = two.Len() - one.Len()
+		return perRow, one.Len() - perRow, nil
+	}
+
+	_, all, err := compile(d, stmt)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, values, err := r.Rows()
+	if err != nil {
+		return 0, 0, err
+	}
+	rest = all.Len()
+	for _, row := range values {
+		ps := Params()
+		for _, value := range row {
+			// Clauses, such as DEFAULT, are compiled as is
+			param, ok := value.(Clause)
+			if !ok {
+				param = NewParam(value)
+			}
+			if _, err = param.Compile(d, ps); err != nil {
+				return 0, 0, err
+			}
+		}
+		rest -= ps.Len()
+		if ps.Len() > perRow {
+			perRow = ps.Len()
+		}
+	}
+	return perRow, rest, nil
+}
+
+// BulkInsert executes the given statement in batches that respect the
+// parameter limit of the connection's dialect. All batches are run
+// within a single transaction, which will be committed only if every
+// batch succeeds. If the connection is already a transaction, it will
+// be used as is. The total number of rows affected is returned.
+//
+// If a destination is given, it must be a pointer to a slice: the rows
+// returned by each batch, such as by a RETURNING clause, will be appended.
+func BulkInsert(conn Conn, stmt Batcher, dest ...interface{}) (int64, error) {
+	if stmt.Len() == 0 {
+		return 0, fmt.Errorf("sol: BulkInsert was given a statement without values")
+	}
+	if len(dest) > 1 {
+		return 0, fmt.Errorf("sol: BulkInsert accepts at most one destination")
+	}
+
+	var total int64
+	err := inTransaction(conn, "BulkInsert", func(exec executer, d dialect.Dialect) (err error) {
+		total, err = bulkInsert(exec, d, stmt, dest...)
+		return
+	})
+	return total, err
+}
+
+// inTransaction calls the given function within a transaction of the
+// connection, which will be committed only if the function succeeds.
+// If the connection is already a transaction, it will be used as is.
+func inTransaction(conn Conn, caller string, fn func(executer, dialect.Dialect) error) error {
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	// Only close the transaction if it was started here
+	if _, isTX := conn.(TX); !isTX {
+		defer tx.Close()
+	}
+
+	inner, ok := tx.(*transaction)
+	if !ok {
+		return fmt.Errorf(
+			"sol: %s requires a connection created by sol.Open", caller,
+		)
+	}
+
+	if err = fn(inner.Tx, inner.dialect); err != nil {
+		return err
+	}
+	if _, isTX := conn.(TX); !isTX {
+		tx.IsSuccessful()
+	}
+	return nil
+}
+
+func bulkInsert(exec executer, d dialect.Dialect, stmt Batcher, dest ...interface{}) (int64, error) {
+	size, err := BatchSize(d, stmt)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	rows := stmt.Len()
+	for i := 0; i < rows; i += size {
+		j := i + size
+		if j > rows {
+			j = rows
+		}
+		var n int64
+		if len(dest) == 0 {
+			n, err = executeBatch(exec, d, stmt.Batch(i, j))
+		} else {
+			n, err = appendBatch(exec, d, stmt.Batch(i, j), dest[0])
+		}
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// executeBatch executes a single batch and returns the rows affected
+func executeBatch(exec executer, d dialect.Dialect, batch Executable) (int64, error) {
+	result, err := execute(exec, d, batch)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// appendBatch queries a single batch and appends the returned rows to
+// the given destination, which must be a pointer to a slice
+func appendBatch(exec executer, d dialect.Dialect, batch Executable, dest interface{}) (int64, error) {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return 0, fmt.Errorf(
+			"sol: BulkInsert destinations must be a pointer to a slice, received %T",
+			dest,
+		)
+	}
+	list := value.Elem()
+
+	// Scan into a new slice, since Result.All will merge into any
+	// existing elements
+	scanned := reflect.New(list.Type())
+	if err := queryAll(exec, d, batch, scanned.Interface()); err != nil {
+		return 0, err
+	}
+	list.Set(reflect.AppendSlice(list, scanned.Elem()))
+	return int64(scanned.Elem().Len()), nil
+}
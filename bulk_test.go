@@ -0,0 +1,113 @@
+package sol
+
+import "testing"
+
+// limitedDialect is a test dialect with a parameter limit
+type limitedDialect struct {
+	defaultDialect
+	limit int
+}
+
+func (d limitedDialect) MaxParams() int {
+	return d.limit
+}
+
+func TestBatchSize(t *testing.T) {
+	rows := []Values{
+		{"id": 1, "name": "a"},
+		{"id": 2, "name": "b"},
+		{"id": 3, "name": "c"},
+		{"id": 4, "name": "d"},
+		{"id": 5, "name": "e"},
+	}
+	stmt := users.Insert().Values(rows)
+
+	// Dialects without a limit will use a single batch
+	size, err := BatchSize(&defaultDialect{}, stmt)
+	if err != nil {
+		t.Fatalf("Unexpected error from BatchSize: %s", err)
+	}
+	if size != 5 {
+		t.Errorf("Unexpected batch size without a limit: %d != 5", size)
+	}
+
+	size, err = BatchSize(limitedDialect{limit: 5}, stmt)
+	if err != nil {
+		t.Fatalf("Unexpected error from BatchSize: %s", err)
+	}
+	if size != 2 {
+		t.Errorf("Unexpected batch size with a limit of 5: %d != 2", size)
+	}
+
+	// A single row that exceeds the limit should error
+	if _, err = BatchSize(limitedDialect{limit: 1}, stmt); err == nil {
+		t.Errorf("BatchSize should error when a row exceeds the limit")
+	}
+
+	// Rows that omit columns use fewer parameters than the others
+	uneven := users.Insert().Values([]Values{
+		{"id": 1, "name": "a"},
+		{"id": 2, "name": "b"},
+		{"id": 3, "name": "c", "email": "c@example.com"},
+		{"id": 4, "name": "d", "email": "d@example.com"},
+		{"id": 5, "name": "e", "email": "e@example.com"},
+	})
+	limited := limitedDialect{limit: 6}
+	size, err = BatchSize(limited, uneven)
+	if err != nil {
+		t.Fatalf("Unexpected error from BatchSize: %s", err)
+	}
+	for i := 0; i < uneven.Len(); i += size {
+		j := i + size
+		if j > uneven.Len() {
+			j = uneven.Len()
+		}
+		_, ps, err := compile(limited, uneven.Batch(i, j))
+		if err != nil {
+			t.Fatalf("Unexpected error compiling a batch: %s", err)
+		}
+		if ps.Len() > limited.limit {
+			t.Errorf("Batch %d:%d exceeds the limit of 6: %d", i, j, ps.Len())
+		}
+	}
+
+	expect := NewTester(t, &defaultDialect{})
+	expect.SQL(
+		stmt.Batch(2, 4),
+		`INSERT INTO users (id, name) VALUES ($1, $2), ($3, $4)`,
+		3, "c", 4, "d",
+	)
+}
+
+// wrappedTX is a transaction that was not created by sol.Open
+type wrappedTX struct {
+	TX
+	closed bool
+}
+
+func (tx *wrappedTX) Close() error {
+	tx.closed = true
+	return nil
+}
+
+// wrappedConn is a connection that begins wrapped transactions
+type wrappedConn struct {
+	Conn
+	tx *wrappedTX
+}
+
+func (c wrappedConn) Begin() (TX, error) {
+	return c.tx, nil
+}
+
+func TestBulkInsert_wrappedConn(t *testing.T) {
+	// Unsupported transactions must still be closed
+	conn := wrappedConn{tx: &wrappedTX{}}
+	stmt := users.Insert().Values(Values{"id": 1, "name": "a"})
+	if _, err := BulkInsert(conn, stmt); err == nil {
+		t.Error("BulkInsert should error when given an unsupported connection")
+	}
+	if !conn.tx.closed {
+		t.Error("BulkInsert should close the transaction it began")
+	}
+}
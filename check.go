@@ -0,0 +1,143 @@
+package sol
+
+import (
+	"fmt"
+
+	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+// CheckConstraint is a table CHECK constraint built from a Clause
+type CheckConstraint struct {
+	name   string
+	clause Clause
+}
+
+var _ types.Type = CheckConstraint{}
+var _ Modifier = CheckConstraint{}
+
+// Create returns the constraint for CREATE TABLE statements. Since a
+// CHECK cannot be parameterized, its values are compiled as literals.
+func (check CheckConstraint) Create(d dialect.Dialect) (string, error) {
+	compiled, err := check.clause.Compile(literalDialect{Dialect: d}, Params())
+	if err != nil {
+		return "", err
+	}
+	return constraintName(check.name) + fmt.Sprintf("CHECK (%s)", compiled), nil
+}
+
+// Modify implements the Modifier interface. It confirms that every
+// column of the clause exists in the table.
+func (check CheckConstraint) Modify(tabular Tabular) error {
+	if tabular == nil || tabular.Table() == nil {
+		return fmt.Errorf("sol: check constraints cannot modify a nil table")
+	}
+	if check.clause == nil {
+		return fmt.Errorf("sol: check constraint %s has a nil clause", check.name)
+	}
+	table := tabular.Table() // Get the dialect neutral table
+	d := literalDialect{Dialect: &defaultDialect{}, table: table}
+	if _, err := check.clause.Compile(d, Params()); err != nil {
+		return err
+	}
+	table.creates = append(table.creates, check)
+	return nil
+}
+
+// Check creates a CHECK constraint with the given name and clause. The
+// name may be empty. Since the table does not exist during its own
+// construction, the clause should be built from its unbound columns:
+//  price := sol.Column("price", types.Integer())
+//  items := sol.Table("items",
+//      price,
+//      sol.Check("positive_price", price.GreaterThan(0)),
+//  )
+// Or added afterwards with Modify:
+//  sol.Check("positive_price", Items.C("price").GreaterThan(0)).Modify(Items)
+func Check(name string, clause Clause) CheckConstraint {
+	return CheckConstraint{name: name, clause: clause}
+}
+
+// constraintName returns the CONSTRAINT prefix of a named constraint
+func constraintName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("CONSTRAINT %s ", name)
+}
+
+// literalDialect wraps a dialect to compile parameters as literal values
+// and columns by name only, as required by table constraints. If a table
+// is given, columns will be confirmed to exist in it.
+type literalDialect struct {
+	dialect.Dialect
+	table *TableElem
+}
+
+// column returns the constraint's name for the given column
+func (d literalDialect) column(col ColumnElem) (string, error) {
+	if d.table != nil && !d.table.Has(col.name) {
+		return "", FieldError{
+			Kind: UnknownColumn, Column: col.name, Table: d.table.name,
+		}
+	}
+	return col.name, nil
+}
+
+// literal returns the given value as a SQL literal of the wrapped dialect
+func (d literalDialect) literal(value interface{}) (string, error) {
+	compiled, err := types.Literal(d.Dialect, value)
+	if err != nil {
+		return "", FieldError{Kind: UnsupportedValue, Msg: err.Error()}
+	}
+	return compiled, nil
+}
+
+// NamedConstraint gives a PRIMARY KEY or UNIQUE constraint a name, so that
+// it can be targeted by statements such as ALTER TABLE ... DROP CONSTRAINT
+type NamedConstraint struct {
+	name       string
+	constraint interface {
+		types.Type
+		Modifier
+	}
+}
+
+var _ types.Type = NamedConstraint{}
+var _ Modifier = NamedConstraint{}
+
+// Create returns the named constraint for CREATE TABLE statements
+func (named NamedConstraint) Create(d dialect.Dialect) (string, error) {
+	compiled, err := named.constraint.Create(d)
+	if err != nil {
+		return "", err
+	}
+	return constraintName(named.name) + compiled, nil
+}
+
+// Modify implements the Modifier interface. The constraint modifies the
+// table as usual before its create is replaced by the named version. It
+// will error unless the constraint adds exactly one create.
+func (named NamedConstraint) Modify(tabular Tabular) error {
+	if tabular == nil || tabular.Table() == nil {
+		return fmt.Errorf("sol: named constraints cannot modify a nil table")
+	}
+	table := tabular.Table() // Get the dialect neutral table
+	index := len(table.creates)
+	if err := named.constraint.Modify(tabular); err != nil {
+		return err
+	}
+	if len(table.creates) != index+1 {
+		return fmt.Errorf(
+			"sol: constraint %s did not add a create to table '%s'",
+			named.name, table.name,
+		)
+	}
+	table.creates[index] = named
+	return nil
+}
+
+// Name returns the name of the constraint
+func (named NamedConstraint) Name() string {
+	return named.name
+}
@@ -0,0 +1,88 @@
+package sol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aodin/sol/types"
+)
+
+// silentConstraint is a constraint that does not add a create
+type silentConstraint struct{ UniqueArray }
+
+func (c silentConstraint) Modify(tabular Tabular) error { return nil }
+
+func TestCheck(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	price := Column("price", types.Integer())
+	items := Table("items",
+		Column("id", types.Integer()),
+		Column("sku", types.Varchar()),
+		price,
+		PrimaryKey("id").Named("items_pkey"),
+		Unique("sku").Named("items_sku_key"),
+		Check("positive_price", price.GreaterThan(0)),
+	)
+	if err := Check("", AnyOf(
+		items.C("sku").Equals("it's"), items.C("sku").IsNull(),
+	)).Modify(items); err != nil {
+		t.Fatalf("Unexpected error during Check Modify(): %s", err)
+	}
+
+	// Values are compiled as literals and columns by name only
+	expect.SQL(
+		items.Create(),
+		`CREATE TABLE items (
+  id INTEGER,
+  sku VARCHAR,
+  price INTEGER,
+  CONSTRAINT items_pkey PRIMARY KEY (id),
+  CONSTRAINT items_sku_key UNIQUE (sku),
+  CONSTRAINT positive_price CHECK (price > 0),
+  CHECK ((sku = 'it''s' OR sku IS NULL))
+);`,
+	)
+
+	// Named constraints still modify the table
+	if pk := items.PrimaryKey(); len(pk) != 1 || pk[0] != "id" {
+		t.Errorf("Unexpected primary key of a named constraint: %v", pk)
+	}
+
+	orders := Table("orders",
+		ForeignKey("item_id", items.C("id")).Named("orders_item_fkey"),
+		SelfForeignKey("parent_id", "item_id").Named("orders_parent_fkey"),
+	)
+	expect.SQL(
+		orders.Create(),
+		`CREATE TABLE orders (
+  item_id INTEGER CONSTRAINT orders_item_fkey REFERENCES items(id),
+  parent_id INTEGER CONSTRAINT orders_parent_fkey REFERENCES orders(item_id)
+);`,
+	)
+
+	// Columns of the CHECK must exist in the table
+	err := Check("", orders.C("item_id").Equals(1)).Modify(items)
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("Check of a missing column should error with ErrUnknownColumn, received: %v", err)
+	}
+	if err := Check("", nil).Modify(items); err == nil {
+		t.Errorf("Check with a nil clause should error")
+	}
+
+	// Named constraints must add exactly one create
+	creates := len(items.creates)
+	silent := NamedConstraint{name: "silent", constraint: silentConstraint{}}
+	if err := silent.Modify(items); err == nil {
+		t.Errorf("Named constraints that do not add a create should error")
+	}
+	if len(items.creates) != creates {
+		t.Errorf("Named constraints should not replace an existing create")
+	}
+
+	// Some values cannot be literals
+	_, err = Check("", price.Equals([]int{1})).Create(&defaultDialect{})
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Check of an unsupported value should error with ErrUnsupportedValue, received: %v", err)
+	}
+}
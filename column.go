@@ -29,16 +29,27 @@ type ColumnElem struct {
 	alias     string
 	table     *TableElem
 	datatype  types.Type
+	comment   string
 	invalid   bool // columns will be assumed valid until otherwise proven
 }
 
 var _ Columnar = ColumnElem{}
 
+// AddOperator prepends the given operator, so it is applied to the
+// column before any existing operators, and returns a copy of the
+// ColumnElem. To wrap the column's current expression, use WrapOperator.
 func (col ColumnElem) AddOperator(op Operator) ColumnElem {
 	col.operators = append([]Operator{op}, col.operators...) // prepend
 	return col
 }
 
+// WrapOperator wraps the column, including its existing operators, in
+// the given operator and returns a copy of the ColumnElem
+func (col ColumnElem) WrapOperator(op Operator) ColumnElem {
+	col.operators = append(append([]Operator{}, col.operators...), op)
+	return col
+}
+
 // Alias returns the Column's alias
 func (col ColumnElem) Alias() string {
 	return col.alias
@@ -62,11 +73,34 @@ func (col ColumnElem) Columns() []ColumnElem {
 	return []ColumnElem{col}
 }
 
+// Comment sets the comment of the column and returns a copy of the
+// ColumnElem. Comments are created with the column's table by dialects
+// that support them.
+//  sol.Column("name", types.Varchar()).Comment("Display name")
+func (col ColumnElem) Comment(text string) ColumnElem {
+	col.comment = text
+	return col
+}
+
 // Compile produces the dialect specific SQL and adds any parameters
 // in the clause to the given Parameters instance
 func (col ColumnElem) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	// Invalid columns cannot be compiled - they were likely created
+	// from a name that does not exist in the table
+	if col.IsInvalid() {
+		return "", unknownColumn(col, "")
+	}
 	str := col.FullName()
+	if ld, ok := d.(literalDialect); ok {
+		var err error
+		if str, err = ld.column(col); err != nil {
+			return "", err
+		}
+	}
 	for _, op := range col.operators {
+		if failing, ok := op.(FailingOperator); ok && failing.Err() != nil {
+			return "", failing.Err()
+		}
 		str = op.Wrap(str)
 	}
 	return str, nil
@@ -79,13 +113,24 @@ func (col ColumnElem) Create(d dialect.Dialect) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	// Enums are constrained by a CHECK without native support
+	enum, ok := col.datatype.(types.Enumerated)
+	if ok && dialect.Enums(d) == dialect.CheckEnum {
+		compiled += " " + enum.Check(d, col.Name())
+	}
+	if col.comment != "" && dialect.Comments(d) == dialect.InlineComment {
+		compiled += " COMMENT " + dialect.Quote(d, col.comment)
+	}
 	return fmt.Sprintf(`%s %s`, col.Name(), compiled), nil
 }
 
 // FullName prefixes the column name with the table name
 // It does not include operators (such as 'max')
 func (col ColumnElem) FullName() string {
-	return fmt.Sprintf(`%s.%s`, col.table.Name(), col.name)
+	if col.table == nil {
+		return col.name
+	}
+	return fmt.Sprintf(`%s.%s`, col.table.reference(), col.name)
 }
 
 // IsInvalid will return true when a column that does not exist was
@@ -94,6 +139,22 @@ func (col ColumnElem) IsInvalid() bool {
 	return col.invalid
 }
 
+// isNotNull returns true if the column is known to be NOT NULL: either
+// its type is NOT NULL or it belongs to its table's primary key
+func (col ColumnElem) isNotNull() bool {
+	if typ, ok := col.datatype.(types.NotNuller); ok && typ.IsNotNull() {
+		return true
+	}
+	if col.table != nil {
+		for _, name := range col.table.pk {
+			if name == col.name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // IsValid returns true unless the column has been marked invalid. It
 // may be a false positive.
 func (col ColumnElem) IsValid() bool {
@@ -134,6 +195,16 @@ func (col ColumnElem) Modify(tabular Tabular) error {
 		return err
 	}
 
+	// Types such as AutoIncrement declare the primary key themselves
+	if keyer, ok := col.datatype.(types.PrimaryKeyer); ok && keyer.IsPrimaryKey() {
+		if len(table.pk) > 0 {
+			return fmt.Errorf(
+				"sol: table '%s' already has a primary key", table.name,
+			)
+		}
+		table.pk = PKArray{col.name}
+	}
+
 	// Add the type to the table creates
 	table.creates = append(table.creates, col)
 
@@ -10,9 +10,13 @@ import (
 // ColumnSet maintains a []ColumnElem. It includes a variety of
 // getters, setters, and filters.
 type ColumnSet struct {
-	order []ColumnElem
+	order           []ColumnElem
+	aliasDuplicates bool
 }
 
+// Compile outputs the columns of the set. If AliasDuplicates was called,
+// any columns that share a name without an explicit alias will be aliased
+// with their table name, e.g. users.id AS "users__id".
 func (set ColumnSet) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
 	names := make([]string, len(set.order))
 	for i, col := range set.order {
@@ -22,12 +26,28 @@ func (set ColumnSet) Compile(d dialect.Dialect, ps *Parameters) (string, error)
 		}
 		if col.Alias() != "" {
 			compiled += fmt.Sprintf(` AS "%s"`, col.Alias())
+		} else if set.aliasDuplicates && set.isDuplicate(col) {
+			compiled += fmt.Sprintf(
+				` AS "%s%s%s"`, col.Table().reference(), aliasSeparator, col.Name(),
+			)
 		}
 		names[i] = compiled
 	}
 	return strings.Join(names, ", "), nil
 }
 
+// isDuplicate returns true if another column in the set without an
+// explicit alias has the same name as the given column
+func (set ColumnSet) isDuplicate(column ColumnElem) bool {
+	var count int
+	for _, col := range set.order {
+		if col.Alias() == "" && col.Name() == column.Name() {
+			count += 1
+		}
+	}
+	return count > 1 && column.Table() != nil
+}
+
 // Add adds any number of Columnar types to the set and returns the new set.
 func (set ColumnSet) Add(columns ...Columnar) (ColumnSet, error) {
 	for _, column := range columns {
@@ -36,6 +56,14 @@ func (set ColumnSet) Add(columns ...Columnar) (ColumnSet, error) {
 	return set, nil
 }
 
+// AliasDuplicates returns a copy of the set that will alias any columns
+// with duplicate names with their table name during compilation, which
+// allows results to be aligned with the fields of each table.
+func (set ColumnSet) AliasDuplicates() ColumnSet {
+	set.aliasDuplicates = true
+	return set
+}
+
 // All returns all columns in their default order
 func (set ColumnSet) All() []ColumnElem {
 	return set.order
@@ -121,6 +149,8 @@ func (set ColumnSet) MakeUnique() (UniqueColumnSet, error) {
 		if column.alias != "" {
 			column.name = column.alias
 			column.alias = ""
+		} else if set.aliasDuplicates && set.isDuplicate(column) {
+			column.name = column.Table().reference() + aliasSeparator + column.name
 		}
 
 		if unique, err = unique.Add(column); err != nil {
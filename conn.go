@@ -182,6 +182,11 @@ func (tx *transaction) Rollback() error {
 	return err
 }
 
+// Dialect returns the transaction's dialect
+func (tx *transaction) Dialect() dialect.Dialect {
+	return tx.dialect
+}
+
 // String returns the compiled Executable using the transaction's dialect.
 // If an error is encountered during compilation, it will return the
 // error instead.
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
 )
 
 // For the Postgres implementation:
@@ -15,6 +16,7 @@ type CreateStmt struct {
 	table       *TableElem
 	ifNotExists bool
 	isTemporary bool
+	deferred    []*TableElem // Foreign keys to these tables are omitted
 }
 
 // String outputs the parameter-less CREATE TABLE statement in a neutral
@@ -39,29 +41,140 @@ func (stmt CreateStmt) Temporary() CreateStmt {
 // because an error occurred during compilation.
 func (stmt CreateStmt) Compile(d dialect.Dialect, p *Parameters) (string, error) {
 	// Compiled elements
-	compiled := make([]string, len(stmt.table.creates))
+	compiled := make([]string, 0, len(stmt.table.creates))
 
-	var err error
-	for i, create := range stmt.table.creates {
-		if compiled[i], err = create.Create(d); err != nil {
+	for _, create := range stmt.table.creates {
+		// Deferred foreign keys are added after the table is created
+		if fk, ok := create.(FKElem); ok && stmt.defers(fk) {
+			if fk.isConstraint() {
+				continue
+			}
+			create = stmt.table.C(fk.name)
+		}
+		c, err := create.Create(d)
+		if err != nil {
 			return "", err
 		}
+		compiled = append(compiled, c)
 	}
 
-	var name string
+	name := "CREATE"
 	if stmt.isTemporary {
-		name = "CREATE TEMPORARY TABLE"
-	} else {
-		name = "CREATE TABLE"
+		name += " TEMPORARY"
+	}
+	var options []string
+	for _, option := range stmt.table.options {
+		if option.isQualifier {
+			name += " " + option.option
+		} else {
+			options = append(options, option.option)
+		}
 	}
+	name += " TABLE"
 	if stmt.ifNotExists {
 		name += " IF NOT EXISTS"
 	}
 
+	// Comments of the table are options in dialects that inline them
+	if dialect.Comments(d) == dialect.InlineComment && stmt.table.comment != "" {
+		options = append(
+			options, "COMMENT="+dialect.Quote(d, stmt.table.comment),
+		)
+	}
+
+	var suffix string
+	if len(options) > 0 {
+		suffix = " " + strings.Join(options, ", ")
+	}
+
 	return fmt.Sprintf(
-		"%s %s (\n  %s\n);",
+		"%s %s (\n  %s\n)%s%s",
 		name,
 		stmt.table.Name(),
 		strings.Join(compiled, ",\n  "),
+		suffix,
+		dialect.Terminate(d),
+	), nil
+}
+
+// Types returns the statements that create the enumerated types of the
+// table's columns, which must be executed before the table is created.
+// Only dialects whose enums are named types, such as postgres, have them.
+func (stmt CreateStmt) Types(d dialect.Dialect) []Executable {
+	if dialect.Enums(d) != dialect.NamedEnum {
+		return nil
+	}
+	var stmts []Executable
+	created := make(map[string]bool)
+	for _, column := range stmt.table.Columns() {
+		enum, ok := column.Type().(types.Enumerated)
+		if !ok || created[enum.Name()] {
+			continue
+		}
+		created[enum.Name()] = true
+		stmts = append(stmts, createTypeStmt{
+			enum: enum, ifNotExists: stmt.ifNotExists,
+		})
+	}
+	return stmts
+}
+
+// Comments returns the COMMENT ON statements of the table and its
+// columns, which must be executed after the table is created. Dialects
+// that declare comments inline, such as MySQL, or that have no comments
+// return no statements.
+func (stmt CreateStmt) Comments(d dialect.Dialect) []Executable {
+	if dialect.Comments(d) != dialect.CommentOn {
+		return nil
+	}
+	var stmts []Executable
+	if stmt.table.comment != "" {
+		stmts = append(stmts, commentStmt{
+			kind: "TABLE", name: stmt.table.Name(), comment: stmt.table.comment,
+		})
+	}
+	for _, column := range stmt.table.Columns() {
+		if column.comment == "" {
+			continue
+		}
+		stmts = append(stmts, commentStmt{
+			kind: "COLUMN", name: column.FullName(), comment: column.comment,
+		})
+	}
+	return stmts
+}
+
+// defers returns true if the foreign key will be added after the table is
+// created
+func (stmt CreateStmt) defers(fk FKElem) bool {
+	for _, table := range stmt.deferred {
+		if fk.references == table {
+			return true
+		}
+	}
+	return false
+}
+
+// createTypeStmt creates an enumerated type
+type createTypeStmt struct {
+	enum        types.Enumerated
+	ifNotExists bool
+}
+
+// Compile outputs the CREATE TYPE statement
+func (stmt createTypeStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	return stmt.enum.CreateType(d, stmt.ifNotExists) + dialect.Terminate(d), nil
+}
+
+// commentStmt comments on a table or column
+type commentStmt struct {
+	kind, name, comment string
+}
+
+// Compile outputs the COMMENT ON statement
+func (stmt commentStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	return fmt.Sprintf(
+		"COMMENT ON %s %s IS %s%s",
+		stmt.kind, stmt.name, dialect.Quote(d, stmt.comment), dialect.Terminate(d),
 	), nil
 }
@@ -1,6 +1,12 @@
 package sol
 
-import "testing"
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/aodin/sol/types"
+)
 
 // Valid schemas are declared in sol_test
 
@@ -20,3 +26,102 @@ func TestCreate(t *testing.T) {
 );`,
 	)
 }
+
+func TestCreate_enum(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+	tasks := Table("tasks",
+		Column("id", types.Integer()),
+		Column("status", types.Enum("status", "todo", "done").NotNull()),
+	)
+
+	// Dialects without native enums use a CHECK constraint
+	expect.SQL(
+		tasks.Create(),
+		`CREATE TABLE tasks (
+  id INTEGER,
+  status VARCHAR(4) NOT NULL CHECK (status IN ('todo', 'done'))
+);`,
+	)
+
+	// Literal values must be allowed by the enum
+	expect.SQL(
+		tasks.Insert().Values(Values{"id": 1, "status": "done"}),
+		`INSERT INTO tasks (id, status) VALUES ($1, $2)`,
+		1, "done",
+	)
+	expect.Error(tasks.Insert().Values(Values{"id": 1, "status": "doing"}))
+	expect.Error(tasks.Update().Values(Values{"status": "doing"}))
+	expect.SQL(
+		tasks.Update().Values(Values{"status": sql.NullString{}}),
+		`UPDATE tasks SET status = $1`,
+		sql.NullString{},
+	)
+
+	_, err := tasks.Insert().Values(Values{"status": "doing"}).Compile(
+		&defaultDialect{}, Params(),
+	)
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Error should match ErrInvalidValue: %v", err)
+	}
+}
+
+// unterminatedDialect omits the terminator of statements
+type unterminatedDialect struct {
+	defaultDialect
+}
+
+func (d unterminatedDialect) Terminator() string {
+	return ""
+}
+
+func TestCreate_options(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+	notes := Table("notes",
+		Column("id", types.Integer()).Comment("Note's id"),
+		Column("body", types.Text()),
+		Comment("User notes"),
+		Qualifier("UNLOGGED"),
+		Option("WITH (fillfactor=70)"),
+	)
+
+	// Comments are separate COMMENT ON statements
+	expect.SQL(
+		notes.Create().IfNotExists(),
+		`CREATE UNLOGGED TABLE IF NOT EXISTS notes (
+  id INTEGER,
+  body TEXT
+) WITH (fillfactor=70);`,
+	)
+	comments := notes.Create().Comments(&defaultDialect{})
+	if len(comments) != 2 {
+		t.Fatalf("Unexpected number of comments: %d != 2", len(comments))
+	}
+	expect.SQL(comments[0], `COMMENT ON TABLE notes IS 'User notes';`)
+	expect.SQL(comments[1], `COMMENT ON COLUMN notes.id IS 'Note''s id';`)
+
+	// Comments are omitted by dialects without them
+	expect = NewTester(t, &plainDialect{})
+	expect.SQL(
+		notes.Create(),
+		`CREATE UNLOGGED TABLE notes (
+  id INTEGER,
+  body TEXT
+) WITH (fillfactor=70);`,
+	)
+	if comments := notes.Create().Comments(&plainDialect{}); len(comments) != 0 {
+		t.Errorf("Unexpected number of comments: %d != 0", len(comments))
+	}
+
+	// The terminator is the dialect's
+	expect = NewTester(t, &unterminatedDialect{})
+	expect.SQL(
+		Table("tags", Column("name", types.Text())).Create().Temporary(),
+		`CREATE TEMPORARY TABLE tags (
+  name TEXT
+)`,
+	)
+
+	if err := Option("").Modify(Table("empty")); err == nil {
+		t.Error("Blank table options should error")
+	}
+}
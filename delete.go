@@ -33,7 +33,7 @@ func (stmt DeleteStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if stmt.where != nil {
 		cc, err := stmt.where.Compile(d, ps)
 		if err != nil {
-			return "", err
+			return "", inClause(err, WHERE)
 		}
 		compiled = append(compiled, WHERE, cc)
 	}
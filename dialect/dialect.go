@@ -3,6 +3,7 @@ package dialect
 import (
 	"fmt"
 	"log"
+	"strings"
 )
 
 // Dialect is the common interface that all database drivers must implement.
@@ -10,6 +11,312 @@ type Dialect interface {
 	Param(int) string
 }
 
+// ParamLimiter is an optional interface for dialects that limit the
+// number of parameters allowed in a single statement.
+type ParamLimiter interface {
+	MaxParams() int
+}
+
+// MaxParams returns the maximum number of parameters allowed in a single
+// statement by the given Dialect. Zero means there is no known limit.
+func MaxParams(d Dialect) int {
+	if limiter, ok := d.(ParamLimiter); ok {
+		return limiter.MaxParams()
+	}
+	return 0
+}
+
+// RowValuer is an optional interface for dialects that support row value
+// comparisons, such as (a, b) > (1, 2).
+type RowValuer interface {
+	RowValues() bool
+}
+
+// RowValues returns true if the given Dialect supports row value
+// comparisons.
+func RowValues(d Dialect) bool {
+	if valuer, ok := d.(RowValuer); ok {
+		return valuer.RowValues()
+	}
+	return false
+}
+
+// DistinctOner is an optional interface for dialects that support
+// SELECT DISTINCT ON (...), such as postgres.
+type DistinctOner interface {
+	DistinctOn() bool
+}
+
+// DistinctOn returns true if the given Dialect supports DISTINCT ON
+func DistinctOn(d Dialect) bool {
+	if oner, ok := d.(DistinctOner); ok {
+		return oner.DistinctOn()
+	}
+	return false
+}
+
+// Locker is an optional interface for dialects that support row locking
+// clauses, such as FOR UPDATE. Locks returns true if the given lock
+// strength is supported.
+type Locker interface {
+	Locks(strength string) bool
+}
+
+// Locks returns true if the given Dialect supports the lock strength
+func Locks(d Dialect, strength string) bool {
+	if locker, ok := d.(Locker); ok {
+		return locker.Locks(strength)
+	}
+	return false
+}
+
+// Arrayer is an optional interface for dialects with array types, such
+// as postgres. Array wraps a slice so that it can be used as a parameter,
+// or a pointer to a slice so that it can be scanned.
+type Arrayer interface {
+	Array(interface{}) interface{}
+}
+
+// Array wraps the given slice, or pointer to a slice, as an array of the
+// given Dialect. It returns false if the Dialect does not support arrays.
+func Array(d Dialect, a interface{}) (interface{}, bool) {
+	if arrayer, ok := d.(Arrayer); ok {
+		return arrayer.Array(a), true
+	}
+	return a, false
+}
+
+// EnumStyle is how a dialect creates enumerated types
+type EnumStyle int
+
+// The following constants are the EnumStyles. Dialects without native
+// enumerated types use a CHECK constraint.
+const (
+	CheckEnum  EnumStyle = iota // VARCHAR with a CHECK constraint
+	InlineEnum                  // An inline ENUM('a', 'b') type, as in MySQL
+	NamedEnum                   // A type created before the table, as in postgres
+)
+
+// Enumer is an optional interface for dialects with native enumerated
+// types.
+type Enumer interface {
+	Enums() EnumStyle
+}
+
+// Enums returns how the given Dialect creates enumerated types
+func Enums(d Dialect) EnumStyle {
+	if enumer, ok := d.(Enumer); ok {
+		return enumer.Enums()
+	}
+	return CheckEnum
+}
+
+// Quoter is an optional interface for dialects with their own escaping
+// of string literals, such as MySQL, which also escapes backslashes.
+type Quoter interface {
+	Quote(string) string
+}
+
+// Quote returns the given string as a literal of the given Dialect. By
+// default, the string is single quoted with any single quotes doubled.
+func Quote(d Dialect, s string) string {
+	if quoter, ok := d.(Quoter); ok {
+		return quoter.Quote(s)
+	}
+	return fmt.Sprintf("'%s'", strings.Replace(s, "'", "''", -1))
+}
+
+// Sequencer is an optional interface for dialects with sequences, such
+// as postgres. NextVal returns the expression of the sequence's next value.
+type Sequencer interface {
+	NextVal(sequence string) string
+}
+
+// NextVal returns the expression of the next value of the given sequence.
+// It returns false if the Dialect does not support sequences.
+func NextVal(d Dialect, sequence string) (string, bool) {
+	if sequencer, ok := d.(Sequencer); ok {
+		return sequencer.NextVal(sequence), true
+	}
+	return "", false
+}
+
+// InsertDefaulter is an optional interface for dialects that allow the
+// DEFAULT keyword in the VALUES of an INSERT.
+type InsertDefaulter interface {
+	InsertDefaults() bool
+}
+
+// InsertDefaults returns true if the given Dialect allows the DEFAULT
+// keyword in the VALUES of an INSERT
+func InsertDefaults(d Dialect) bool {
+	if defaulter, ok := d.(InsertDefaulter); ok {
+		return defaulter.InsertDefaults()
+	}
+	return false
+}
+
+// AutoIncrementer is an optional interface for dialects with integer
+// primary keys that are generated by the database. AutoIncrement returns
+// the column's complete type, including its PRIMARY KEY.
+type AutoIncrementer interface {
+	AutoIncrement() string
+}
+
+// AutoIncrement returns the type of an auto-incrementing primary key of
+// the given Dialect. It returns false if the Dialect does not support them.
+func AutoIncrement(d Dialect) (string, bool) {
+	if incrementer, ok := d.(AutoIncrementer); ok {
+		return incrementer.AutoIncrement(), true
+	}
+	return "", false
+}
+
+// Returner is an optional interface for dialects that support RETURNING
+// clauses on INSERT statements, such as postgres.
+type Returner interface {
+	Returning() bool
+}
+
+// Returning returns true if the given Dialect supports RETURNING
+func Returning(d Dialect) bool {
+	if returner, ok := d.(Returner); ok {
+		return returner.Returning()
+	}
+	return false
+}
+
+// Collater is an optional interface for dialects that must quote the
+// names of collations, such as postgres, where names are case sensitive.
+type Collater interface {
+	Collation(name string) string
+}
+
+// Collation returns the name of the collation as it should be output in
+// a COLLATE clause of the given Dialect. By default, it is unchanged.
+func Collation(d Dialect, name string) string {
+	if collater, ok := d.(Collater); ok {
+		return collater.Collation(name)
+	}
+	return name
+}
+
+// CommentStyle is how a dialect creates table and column comments
+type CommentStyle int
+
+// The following constants are the CommentStyles. Comments are omitted by
+// dialects without them, such as sqlite3.
+const (
+	NoComment     CommentStyle = iota // Comments are not created
+	InlineComment                     // An inline COMMENT 'text', as in MySQL
+	CommentOn                         // A COMMENT ON statement, as in postgres
+)
+
+// Commenter is an optional interface for dialects with table and column
+// comments.
+type Commenter interface {
+	Comments() CommentStyle
+}
+
+// Comments returns how the given Dialect creates comments
+func Comments(d Dialect) CommentStyle {
+	if commenter, ok := d.(Commenter); ok {
+		return commenter.Comments()
+	}
+	return NoComment
+}
+
+// Terminator is an optional interface for dialects with a statement
+// terminator other than a semicolon.
+type Terminator interface {
+	Terminator() string
+}
+
+// Terminate returns the statement terminator of the given Dialect. By
+// default, it is a semicolon.
+func Terminate(d Dialect) string {
+	if terminator, ok := d.(Terminator); ok {
+		return terminator.Terminator()
+	}
+	return ";"
+}
+
+// ForwardReferencer is an optional interface for dialects that allow
+// foreign keys to reference tables that have not yet been created, such
+// as sqlite3.
+type ForwardReferencer interface {
+	ForwardReferences() bool
+}
+
+// ForwardReferences returns true if the given Dialect allows foreign keys
+// to reference tables that do not exist
+func ForwardReferences(d Dialect) bool {
+	if referencer, ok := d.(ForwardReferencer); ok {
+		return referencer.ForwardReferences()
+	}
+	return false
+}
+
+// Deferrer is an optional interface for dialects that allow foreign keys
+// to be DEFERRABLE, such as postgres and sqlite3.
+type Deferrer interface {
+	Deferrable() bool
+}
+
+// Deferrable returns true if the given Dialect allows foreign keys to be
+// checked at the end of the transaction
+func Deferrable(d Dialect) bool {
+	if deferrer, ok := d.(Deferrer); ok {
+		return deferrer.Deferrable()
+	}
+	return false
+}
+
+// Virtualizer is an optional interface for dialects that allow generated
+// columns to be VIRTUAL, such as MySQL and sqlite3.
+type Virtualizer interface {
+	VirtualColumns() bool
+}
+
+// VirtualColumns returns true if the given Dialect allows generated
+// columns that are computed when they are read
+func VirtualColumns(d Dialect) bool {
+	if virtualizer, ok := d.(Virtualizer); ok {
+		return virtualizer.VirtualColumns()
+	}
+	return false
+}
+
+// ViewReplacer is an optional interface for dialects that support
+// CREATE OR REPLACE VIEW, such as postgres and MySQL.
+type ViewReplacer interface {
+	ReplaceViews() bool
+}
+
+// ReplaceViews returns true if the given Dialect supports CREATE OR
+// REPLACE VIEW
+func ReplaceViews(d Dialect) bool {
+	if replacer, ok := d.(ViewReplacer); ok {
+		return replacer.ReplaceViews()
+	}
+	return false
+}
+
+// Schemer is an optional interface for dialects with an information
+// schema. CurrentSchema returns the expression of the current schema.
+type Schemer interface {
+	CurrentSchema() string
+}
+
+// CurrentSchema returns the expression of the current schema of the
+// given Dialect. It returns false if the Dialect does not have one.
+func CurrentSchema(d Dialect) (string, bool) {
+	if schemer, ok := d.(Schemer); ok {
+		return schemer.CurrentSchema(), true
+	}
+	return "", false
+}
+
 // Registry of available dialects
 var dialects = make(map[string]Dialect)
 
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/aodin/sol/types"
 )
 
 // ErrNoColumns is returned when attempting to compile a query without
@@ -12,27 +14,218 @@ var ErrNoColumns = errors.New(
 	"sol: cannot compile a statement without columns",
 )
 
-type fieldError struct {
-	column string
-	table  string
-	clause string
+// The following errors are the targets of errors.Is for each ErrorKind
+var (
+	ErrUnknownColumn    = errors.New("sol: unknown column")
+	ErrWrongTable       = errors.New("sol: column belongs to the wrong table")
+	ErrNilSelectable    = errors.New("sol: nil selectable")
+	ErrUnsupportedValue = errors.New("sol: unsupported value type")
+	ErrInvalidValue     = errors.New("sol: invalid value")
+	ErrGeneratedColumn  = errors.New("sol: generated column")
+)
+
+// ErrorKind classifies the problems found while building a statement
+type ErrorKind int
+
+// The following constants represent the possible kinds of FieldError.
+// InvalidStatement is used for any problem without a more specific kind.
+const (
+	InvalidStatement ErrorKind = iota
+	UnknownColumn
+	WrongTable
+	NilSelectable
+	UnsupportedValue
+	InvalidValue
+	GeneratedColumn
+)
+
+// String returns the name of the ErrorKind
+func (kind ErrorKind) String() string {
+	switch kind {
+	case UnknownColumn:
+		return "unknown column"
+	case WrongTable:
+		return "wrong table"
+	case NilSelectable:
+		return "nil selectable"
+	case UnsupportedValue:
+		return "unsupported value"
+	case InvalidValue:
+		return "invalid value"
+	case GeneratedColumn:
+		return "generated column"
+	}
+	return "invalid statement"
+}
+
+// sentinel returns the error that errors.Is will match for the kind
+func (kind ErrorKind) sentinel() error {
+	switch kind {
+	case UnknownColumn:
+		return ErrUnknownColumn
+	case WrongTable:
+		return ErrWrongTable
+	case NilSelectable:
+		return ErrNilSelectable
+	case UnsupportedValue:
+		return ErrUnsupportedValue
+	case InvalidValue:
+		return ErrInvalidValue
+	case GeneratedColumn:
+		return ErrGeneratedColumn
+	}
+	return nil
+}
+
+// FieldError is a single problem found while building or compiling a
+// statement. Column, Table, and Clause will be set when known.
+type FieldError struct {
+	Kind   ErrorKind
+	Column string
+	Table  string
+	Clause string
+	Msg    string
+}
+
+var _ error = FieldError{}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	switch e.Kind {
+	case UnknownColumn:
+		return fmt.Sprintf("sol: column %s does not exist", e.name())
+	case WrongTable:
+		return fmt.Sprintf(
+			"sol: column %s does not belong to table %s", e.Column, e.Table,
+		)
+	case GeneratedColumn:
+		return fmt.Sprintf(
+			"sol: column %s is generated and cannot be given a value", e.name(),
+		)
+	case NilSelectable:
+		if e.Clause != "" {
+			return fmt.Sprintf("sol: received a nil selectable in %s", e.Clause)
+		}
+		return "sol: received a nil selectable"
+	}
+	return fmt.Sprintf("sol: %s", e.Kind)
+}
+
+// Unwrap allows the FieldError to be matched by the sentinel error of
+// its kind, such as ErrUnknownColumn, with errors.Is
+func (e FieldError) Unwrap() error {
+	return e.Kind.sentinel()
+}
+
+// name returns the column name prefixed by its table, if one is known
+func (e FieldError) name() string {
+	if e.Table == "" {
+		return e.Column
+	}
+	return fmt.Sprintf("%s.%s", e.Table, e.Column)
+}
+
+// invalidEnum returns an InvalidValue FieldError if the given value is
+// a literal string that is not allowed by the column's enumerated type
+func invalidEnum(col ColumnElem, value interface{}) error {
+	enum, ok := col.Type().(types.Enumerated)
+	if !ok {
+		return nil
+	}
+	str, ok := value.(string)
+	if !ok || enum.Has(str) {
+		return nil
+	}
+	e := FieldError{
+		Kind:   InvalidValue,
+		Column: col.name,
+		Msg: fmt.Sprintf(
+			"sol: %q is not a value of enum %s (%s)",
+			str, enum.Name(), strings.Join(enum.Values(), ", "),
+		),
+	}
+	if col.table != nil {
+		e.Table = col.table.name
+	}
+	return e
+}
+
+// unknownColumn creates an UnknownColumn FieldError for the given column
+func unknownColumn(col ColumnElem, clause string) FieldError {
+	e := FieldError{Kind: UnknownColumn, Column: col.name, Clause: clause}
+	if col.table != nil {
+		e.Table = col.table.name
+	}
+	return e
 }
 
-type stmtErrors struct {
-	meta   []string
-	fields map[fieldError]string
+// CompileError is the error returned by statements that had one or more
+// problems while they were built. It can be unwrapped into its
+// individual FieldErrors with errors.As.
+type CompileError struct {
+	Errors []FieldError
 }
 
+var _ error = CompileError{}
+
 // Error implements the error interface
-func (e stmtErrors) Error() string {
-	errs := e.meta
-	for field, err := range e.fields {
-		errs = append(errs, fmt.Sprintf("%s (%s)", err, field))
+func (e CompileError) Error() string {
+	errs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err.Error()
 	}
 	return strings.Join(errs, "; ")
 }
 
-// Exist returns true if there are either meta or fields errors
-func (e stmtErrors) Exist() bool {
-	return len(e.meta) > 0 || len(e.fields) > 0
+// Exist returns true if there are any errors
+func (e CompileError) Exist() bool {
+	return len(e.Errors) > 0
+}
+
+// Unwrap returns each FieldError, allowing both errors.Is and errors.As
+// to examine the individual problems
+func (e CompileError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// add appends the given error. FieldError and CompileError types will
+// keep their structure, other errors are added as InvalidStatement.
+func (e *CompileError) add(err error) {
+	switch converted := err.(type) {
+	case FieldError:
+		e.Errors = append(e.Errors, converted)
+	case CompileError:
+		e.Errors = append(e.Errors, converted.Errors...)
+	default:
+		e.Errors = append(e.Errors, FieldError{Msg: err.Error()})
+	}
+}
+
+// inClause sets the clause of any FieldError in the given error that
+// does not already have one
+func inClause(err error, clause string) error {
+	switch converted := err.(type) {
+	case FieldError:
+		if converted.Clause == "" {
+			converted.Clause = clause
+		}
+		return converted
+	case CompileError:
+		errs := make([]FieldError, len(converted.Errors))
+		for i, e := range converted.Errors {
+			if e.Clause == "" {
+				e.Clause = clause
+			}
+			errs[i] = e
+		}
+		return CompileError{Errors: errs}
+	}
+	return err
 }
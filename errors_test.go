@@ -0,0 +1,67 @@
+package sol
+
+import (
+	"errors"
+	"testing"
+)
+
+// All schemas are declared in sol_test.go
+
+func TestCompileError(t *testing.T) {
+	// Invalid columns in an ORDER BY should produce a structured error
+	stmt := users.Select().OrderBy(users.C("nope").Desc())
+	_, err := stmt.Compile(&defaultDialect{}, Params())
+	if err == nil {
+		t.Fatal("Expected an error when ordering by an unknown column")
+	}
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("Error should match ErrUnknownColumn: %s", err)
+	}
+
+	var fieldErr FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("Error should be a FieldError: %T", err)
+	}
+	if fieldErr.Kind != UnknownColumn {
+		t.Errorf("Unexpected kind: %s != %s", fieldErr.Kind, UnknownColumn)
+	}
+	if fieldErr.Column != "nope" || fieldErr.Table != "users" {
+		t.Errorf(
+			"Unexpected column and table: %s.%s != users.nope",
+			fieldErr.Table, fieldErr.Column,
+		)
+	}
+	if fieldErr.Clause != ORDERBY {
+		t.Errorf("Unexpected clause: %s != %s", fieldErr.Clause, ORDERBY)
+	}
+
+	// Errors that occur while building a statement are collected
+	_, err = Select(users.C("email"), nil).Compile(
+		&defaultDialect{}, Params(),
+	)
+	if !errors.Is(err, ErrNilSelectable) {
+		t.Errorf("Error should match ErrNilSelectable: %s", err)
+	}
+
+	_, err = users.Update().Values(Values{"a": 1, "b": 2}).Compile(
+		&defaultDialect{}, Params(),
+	)
+	var compileErr CompileError
+	if !errors.As(err, &compileErr) {
+		t.Fatalf("Error should be a CompileError: %T", err)
+	}
+	if len(compileErr.Errors) != 2 {
+		t.Errorf(
+			"Unexpected length of compile errors: %d != 2",
+			len(compileErr.Errors),
+		)
+	}
+
+	_, err = users.Insert().Values(3).Compile(&defaultDialect{}, Params())
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Error should match ErrUnsupportedValue: %s", err)
+	}
+	if errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("Error should not match ErrUnknownColumn: %s", err)
+	}
+}
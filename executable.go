@@ -12,6 +12,12 @@ type Executable interface {
 	Compiles
 }
 
+// Destination is implemented by types that scan query results themselves.
+// It can be given to Query in place of a struct or slice destination.
+type Destination interface {
+	ScanResult(Result) error
+}
+
 // executer is a common interface that database/sql *DB and *Tx can share
 type executer interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
@@ -48,6 +54,10 @@ func perform(exec executer, d dialect.Dialect, stmt Executable, dest ...interfac
 		return queryAll(exec, d, stmt, dest)
 	}
 
+	if scanner, ok := dest[0].(Destination); ok {
+		return queryDestination(exec, d, stmt, scanner)
+	}
+
 	t := reflect.Indirect(reflect.ValueOf(dest[0]))
 	if t.Kind() == reflect.Slice {
 		return queryAll(exec, d, stmt, dest[0])
@@ -66,7 +76,7 @@ func query(exec executer, d dialect.Dialect, stmt Executable) (*Result, error) {
 		return nil, err
 	}
 	// Wrap the sql rows in a result
-	return &Result{Scanner: rows, stmt: compiled}, nil
+	return &Result{Scanner: rows, stmt: compiled, dialect: d}, nil
 }
 
 // QueryAll will query the statement and populate the given destination
@@ -90,3 +100,14 @@ func queryOne(exec executer, d dialect.Dialect, stmt Executable, dest interface{
 	defer result.Close()
 	return result.One(dest)
 }
+
+// queryDestination will query the statement and allow the given
+// Destination to scan the results.
+func queryDestination(exec executer, d dialect.Dialect, stmt Executable, dest Destination) error {
+	result, err := query(exec, d, stmt)
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+	return dest.ScanResult(*result)
+}
@@ -2,9 +2,13 @@ package sol
 
 import (
 	"reflect"
+	"strings"
 	"time"
 
 	"database/sql"
+	"database/sql/driver"
+
+	"github.com/aodin/sol/dialect"
 )
 
 const (
@@ -12,13 +16,22 @@ const (
 	ignoreTag = "-"
 )
 
+// aliasSeparator separates the table and column names of aliases that
+// are created for duplicate column names, e.g. users__id
+const aliasSeparator = "__"
+
 var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+var timeType = reflect.TypeOf(time.Time{})
 
-// Field holds value and type info on a struct field
+// Field holds value and type info on a struct field. Table will be set
+// if the field's tag includes a table name (e.g. db:"users.name") or the
+// field belongs to a struct tagged with a table name (e.g. db:"users").
 type Field struct {
 	Value   reflect.Value
 	Type    reflect.StructField
 	Name    string
+	Table   string
 	Options options
 }
 
@@ -32,6 +45,45 @@ func (field Field) IsIgnorable() bool {
 	return field.Name == ignoreTag
 }
 
+// IsJSON returns true if the field will be encoded and decoded as JSON.
+// Fields with the json option and maps that are not already a
+// driver.Valuer are JSON.
+func (field Field) IsJSON() bool {
+	if field.Options.Has(JSON) {
+		return true
+	}
+	typ := field.Type.Type
+	return typ.Kind() == reflect.Map && !typ.Implements(valuerType)
+}
+
+// Interface returns the field's value as a parameter. JSON fields will
+// be encoded when they are sent to the database.
+func (field Field) Interface() interface{} {
+	if field.IsJSON() {
+		return jsonValue{field.Value.Interface()}
+	}
+	return field.Value.Interface()
+}
+
+// scanDest returns the destination for scanning the field given a
+// pointer to its value. JSON fields will be decoded, and slices will be
+// scanned as arrays if the dialect supports them.
+func (field Field) scanDest(d dialect.Dialect, ptr interface{}) interface{} {
+	if field.IsJSON() {
+		return jsonScanner{ptr}
+	}
+	return arrayDest(d, ptr)
+}
+
+// arrayDest wraps a pointer to a slice as an array of the dialect, if
+// the dialect supports arrays
+func arrayDest(d dialect.Dialect, ptr interface{}) interface{} {
+	if isArray(reflect.TypeOf(ptr).Elem()) {
+		ptr, _ = dialect.Array(d, ptr)
+	}
+	return ptr
+}
+
 // IsOmittable returns true if the field can be omitted
 func (field Field) IsOmittable() bool {
 	return field.Options.Has(OmitEmpty) && isEmptyValue(field.Value)
@@ -44,6 +96,8 @@ func NewField(val reflect.Value, typ reflect.StructField, index ...int) (field F
 	field.Name, field.Options = parseTag(typ.Tag.Get(tagLabel))
 	if field.Name == "" {
 		field.Name = field.Type.Name // Fallback to struct field name
+	} else if !field.IsIgnorable() {
+		field.Table, field.Name = splitName(field.Name)
 	}
 	// Prepend the given index values to the type index
 	field.Type.Index = append(index, field.Type.Index...)
@@ -53,6 +107,12 @@ func NewField(val reflect.Value, typ reflect.StructField, index ...int) (field F
 // DeepFields returns value and type info on struct types. It will return
 // nothing if the given object is not a struct or *struct type.
 func DeepFields(obj interface{}, index ...int) (fields []Field) {
+	return deepFields(obj, "", index...)
+}
+
+// deepFields returns the fields of the given struct. Fields without a
+// table of their own will be given the table of their parent struct.
+func deepFields(obj interface{}, table string, index ...int) (fields []Field) {
 	val := reflect.ValueOf(obj)
 	typ := reflect.TypeOf(obj)
 	if typ != nil && typ.Kind() == reflect.Ptr {
@@ -70,12 +130,21 @@ func DeepFields(obj interface{}, index ...int) (fields []Field) {
 		if field.IsIgnorable() {
 			continue
 		}
+		if field.Table == "" {
+			field.Table = table
+		}
 
 		// Skip fields that cannot be interfaced
 		if !field.Value.CanInterface() {
 			continue
 		}
 
+		// JSON fields are never flattened
+		if field.IsJSON() {
+			fields = append(fields, field)
+			continue
+		}
+
 		// Time structs have special handling
 		switch field.Value.Interface().(type) {
 		case time.Time, *time.Time:
@@ -89,11 +158,17 @@ func DeepFields(obj interface{}, index ...int) (fields []Field) {
 			continue
 		}
 
-		// Save the field or recurse further
+		// Save the field or recurse further. A nested struct with a
+		// tagged name will pass that name as the table of its fields.
 		switch field.Value.Kind() {
 		case reflect.Struct:
-			fields = append(fields, DeepFields(
+			nested := field.Table
+			if name, _ := parseTag(field.Type.Tag.Get(tagLabel)); name != "" {
+				nested = name
+			}
+			fields = append(fields, deepFields(
 				field.Value.Interface(),
+				nested,
 				field.Type.Index...,
 			)...)
 		default:
@@ -105,21 +180,84 @@ func DeepFields(obj interface{}, index ...int) (fields []Field) {
 
 // AlignFields will reorder the given fields array to match the columns.
 // Columns that do not match fields will be given empty field structs.
+// Columns aliased with their table, such as users__id, will first be
+// matched to fields of that table. Each field will be matched at most
+// once, so duplicate column names are aligned with fields in order.
+// Embedded structs only have a table if they are tagged with one, such
+// as User `db:"users"`; otherwise their aliased columns are aligned in
+// the order they were selected.
 func AlignFields(columns []string, fields []Field) []Field {
 	out := make([]Field, len(columns))
+	for i, j := range alignIndexes(columns, fields) {
+		if j != -1 {
+			out[i] = fields[j]
+		}
+	}
+	return out
+}
+
+// alignIndexes returns the index of the field matched to each column, or
+// -1 if no field was matched
+func alignIndexes(columns []string, fields []Field) []int {
+	out := make([]int, len(columns))
+	used := make([]bool, len(fields))
+
+	align := func(i int, table, name string) {
+		if j := matchField(fields, used, table, name); j != -1 {
+			out[i] = j
+			used[j] = true
+		}
+	}
 
+	// Columns with a table alias take precedence
 	for i, column := range columns {
-		for _, field := range fields {
-			// Match names either exactly and using camel to snake
-			if field.Name == column || camelToSnake(field.Name) == column {
-				out[i] = field
-				break
-			}
+		out[i] = -1
+		if table, name := splitAlias(column); table != "" {
+			align(i, table, name)
+		}
+	}
+
+	for i, column := range columns {
+		if out[i] != -1 {
+			continue
+		}
+		align(i, "", column)
+		if out[i] != -1 {
+			continue
+		}
+		if _, name := splitAlias(column); name != column {
+			align(i, "", name)
 		}
 	}
 	return out
 }
 
+// matchField returns the index of the first unused field that matches the
+// given column name and table, or -1 if none match. Any table will match
+// if the given table is blank.
+func matchField(fields []Field, used []bool, table, column string) int {
+	for j, field := range fields {
+		if used[j] || (table != "" && field.Table != table) {
+			continue
+		}
+		// Match names either exactly and using camel to snake
+		if field.Name == column || camelToSnake(field.Name) == column {
+			return j
+		}
+	}
+	return -1
+}
+
+// splitAlias separates an alias created for duplicate column names into
+// its table and column names
+func splitAlias(alias string) (string, string) {
+	parts := strings.SplitN(alias, aliasSeparator, 2)
+	if len(parts) > 1 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1]
+	}
+	return "", alias
+}
+
 // NoMatchingFields returns true if no fields exist
 func NoMatchingFields(fields []Field) bool {
 	for _, field := range fields {
@@ -1,6 +1,8 @@
 package sol
 
 import (
+	"reflect"
+	"testing"
 	"time"
 )
 
@@ -35,3 +37,81 @@ type embedded struct {
 	Metadata metadata `db:"-"`
 	Deep     Nested
 }
+
+func TestAlignFields(t *testing.T) {
+	type joined struct {
+		User struct {
+			ID   int64
+			Name string
+		} `db:"users"`
+		Contact struct {
+			ID     int64
+			UserID int64
+			Value  string `db:"contacts.value"`
+		}
+	}
+
+	fields := DeepFields(joined{})
+	if fields[0].Table != "users" || fields[1].Table != "users" {
+		t.Errorf("Fields of a tagged struct should inherit its table")
+	}
+	if fields[4].Table != "contacts" || fields[4].Name != "value" {
+		t.Errorf(
+			"Unexpected table and name of a prefixed tag: %s.%s",
+			fields[4].Table, fields[4].Name,
+		)
+	}
+
+	// Aliased columns should match fields of their table
+	aligned := AlignFields(
+		[]string{"contacts__id", "users__id", "name", "user_id", "value"},
+		fields,
+	)
+	want := [][]int{{1, 0}, {0, 0}, {0, 1}, {1, 1}, {1, 2}}
+	for i, field := range aligned {
+		if !reflect.DeepEqual(field.Type.Index, want[i]) {
+			t.Errorf(
+				"Unexpected field index for column %d: %v != %v",
+				i, field.Type.Index, want[i],
+			)
+		}
+	}
+
+	// Embedded structs tagged with their table are aligned regardless of
+	// the order of the selected columns
+	type User struct {
+		ID   int64
+		Name string
+	}
+	type Contact struct {
+		ID    int64
+		Value string
+	}
+	embedded := DeepFields(struct {
+		User    `db:"users"`
+		Contact `db:"contacts"`
+	}{})
+	aligned = AlignFields(
+		[]string{"contacts__id", "value", "users__id", "name"}, embedded,
+	)
+	want = [][]int{{1, 0}, {1, 1}, {0, 0}, {0, 1}}
+	for i, field := range aligned {
+		if !reflect.DeepEqual(field.Type.Index, want[i]) {
+			t.Errorf(
+				"Unexpected embedded field index for column %d: %v != %v",
+				i, field.Type.Index, want[i],
+			)
+		}
+	}
+
+	// Duplicate column names without aliases are aligned in order
+	aligned = AlignFields([]string{"id", "id", "id"}, fields)
+	if !reflect.DeepEqual(aligned[1].Type.Index, []int{1, 0}) {
+		t.Errorf(
+			"The second id column should align with the second id field",
+		)
+	}
+	if aligned[2].Exists() {
+		t.Errorf("A third id column should not align with any field")
+	}
+}
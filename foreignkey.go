@@ -3,6 +3,7 @@ package sol
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/aodin/sol/dialect"
 	"github.com/aodin/sol/types"
@@ -26,41 +27,130 @@ var _ types.Type = FKElem{}
 // types.Type interface so it can be used in CREATE TABLE statements.
 type FKElem struct {
 	name       string
+	constraint string // optional name of the constraint
 	col        ColumnElem
 	datatype   types.Type
 	table      *TableElem // the parent table of the key
 	references *TableElem // the table the key references
 	onDelete   *fkAction
 	onUpdate   *fkAction
+	deferrable bool
+
+	// Keys created by ForeignKeyConstraint reference all of their columns
+	names []string
+	cols  []ColumnElem
 }
 
 // Create returns the element's syntax for a CREATE TABLE statement.
 func (fk FKElem) Create(d dialect.Dialect) (string, error) {
+	if fk.isConstraint() {
+		return fk.tableConstraint(d, fk.constraint)
+	}
+
 	// Compile the type
 	ct, err := fk.datatype.Create(d)
 	if err != nil {
 		return "", err
 	}
+	actions, err := fk.actions(d)
+	if err != nil {
+		return "", err
+	}
 	compiled := fmt.Sprintf(
-		`%s %s REFERENCES %s(%s)`,
+		`%s %s %sREFERENCES %s(%s)`,
 		fk.name,
 		ct,
+		constraintName(fk.constraint),
 		fk.col.Table().Name(),
 		fk.col.Name(),
 	)
+	return compiled + actions, nil
+}
+
+// tableConstraint returns the foreign key as a table constraint with the
+// given name, which may be empty
+func (fk FKElem) tableConstraint(d dialect.Dialect, name string) (string, error) {
+	actions, err := fk.actions(d)
+	if err != nil {
+		return "", err
+	}
+	compiled := fmt.Sprintf(
+		`%sFOREIGN KEY (%s) REFERENCES %s(%s)`,
+		constraintName(name),
+		strings.Join(fk.Names(), ", "),
+		fk.references.Name(),
+		strings.Join(fk.ForeignNames(), ", "),
+	)
+	return compiled + actions, nil
+}
+
+// actions returns the ON DELETE, ON UPDATE and DEFERRABLE clauses of
+// the foreign key. It will error if the foreign key is deferrable and
+// the dialect does not support it.
+func (fk FKElem) actions(d dialect.Dialect) (compiled string, err error) {
 	if fk.onDelete != nil {
 		compiled += fmt.Sprintf(" ON DELETE %s", *fk.onDelete)
 	}
 	if fk.onUpdate != nil {
 		compiled += fmt.Sprintf(" ON UPDATE %s", *fk.onUpdate)
 	}
-	return compiled, nil
+	if fk.deferrable {
+		if !dialect.Deferrable(d) {
+			return "", FieldError{
+				Column: strings.Join(fk.Names(), ", "),
+				Msg: fmt.Sprintf(
+					"sol: the dialect %T does not support DEFERRABLE foreign keys", d,
+				),
+			}
+		}
+		compiled += " DEFERRABLE INITIALLY DEFERRED"
+	}
+	return
+}
+
+// Deferrable makes the foreign key DEFERRABLE INITIALLY DEFERRED, so it
+// will not be checked until the end of the transaction. Dialects that do
+// not support deferrable foreign keys, such as MySQL, will error.
+func (fk FKElem) Deferrable() FKElem {
+	fk.deferrable = true
+	return fk
 }
 
 func (fk FKElem) ForeignName() string {
 	return fk.col.Name()
 }
 
+// ForeignNames returns the names of the referenced columns in order
+func (fk FKElem) ForeignNames() []string {
+	if !fk.isConstraint() {
+		return []string{fk.col.Name()}
+	}
+	names := make([]string, len(fk.cols))
+	for i, col := range fk.cols {
+		names[i] = col.Name()
+	}
+	return names
+}
+
+// IsComposite returns true if the foreign key has multiple columns
+func (fk FKElem) IsComposite() bool {
+	return len(fk.names) > 1
+}
+
+// isConstraint returns true if the foreign key was created as a table
+// constraint rather than with its column
+func (fk FKElem) isConstraint() bool {
+	return len(fk.names) > 0
+}
+
+// Names returns the names of the foreign key's columns in order
+func (fk FKElem) Names() []string {
+	if !fk.isConstraint() {
+		return []string{fk.name}
+	}
+	return fk.names
+}
+
 // References returns the table that this foreign key references.
 func (fk FKElem) References() *TableElem {
 	return fk.references
@@ -74,19 +164,36 @@ func (fk FKElem) Modify(tabular Tabular) error {
 	}
 	table := tabular.Table() // Get the dialect neutral table
 
-	if err := isValidColumnName(fk.name); err != nil {
-		return err
-	}
-
 	// Add the table to the foreign key
 	if fk.table != nil && fk.table != table {
 		return fmt.Errorf(
 			"sol: foreign key %s already belongs to table %s",
-			fk.name, fk.table.name,
+			strings.Join(fk.Names(), ", "), fk.table.name,
 		)
 	}
 	fk.table = table
 
+	// Constraints are created from existing columns
+	if fk.isConstraint() {
+		for _, name := range fk.names {
+			if !table.Has(name) {
+				return fmt.Errorf(
+					"sol: table '%s' does not have a column '%s'. Is it created after the ForeignKeyConstraint?",
+					table.name,
+					name,
+				)
+			}
+		}
+		table.creates = append(table.creates, fk)
+		table.fks = append(table.fks, fk)
+		fk.references.referencedBy = append(fk.references.referencedBy, fk)
+		return nil
+	}
+
+	if err := isValidColumnName(fk.name); err != nil {
+		return err
+	}
+
 	// Create the column for this table
 	col := ColumnElem{
 		name:     fk.name,
@@ -112,6 +219,12 @@ func (fk FKElem) Modify(tabular Tabular) error {
 	return nil
 }
 
+// Named sets the name of the foreign key constraint
+func (fk FKElem) Named(name string) FKElem {
+	fk.constraint = name
+	return fk
+}
+
 // OnDelete adds an ON DELETE clause to the foreign key
 func (fk FKElem) OnDelete(b fkAction) FKElem {
 	fk.onDelete = &b
@@ -171,6 +284,48 @@ func ForeignKey(name string, fk Selectable, datatypes ...types.Type) FKElem {
 	}
 }
 
+// ForeignKeyConstraint creates a composite foreign key from the given
+// columns of the table to the referenced columns of another table. If no
+// referenced columns are given, the primary key of the table is used.
+// The columns must be created before the constraint:
+//  sol.ForeignKeyConstraint(
+//      []string{"order_id", "line"}, OrderLines, []string{"order_id", "line"},
+//  ).OnDelete(sol.Cascade)
+func ForeignKeyConstraint(names []string, ref Tabular, refNames []string) FKElem {
+	if ref == nil || ref.Table() == nil {
+		log.Panic("sol: foreign key constraint was given a nil table")
+	}
+	table := ref.Table()
+	if len(refNames) == 0 {
+		refNames = table.PrimaryKey()
+	}
+	if len(names) == 0 || len(names) != len(refNames) {
+		log.Panicf(
+			"sol: foreign key constraint has %d columns but references %d columns",
+			len(names), len(refNames),
+		)
+	}
+
+	cols := make([]ColumnElem, len(refNames))
+	for i, name := range refNames {
+		if !table.Has(name) {
+			log.Panicf(
+				"sol: table %s does not have a column %s to reference",
+				table.name, name,
+			)
+		}
+		cols[i] = table.C(name)
+	}
+
+	return FKElem{
+		name:       names[0],
+		col:        cols[0],
+		references: table,
+		names:      names,
+		cols:       cols,
+	}
+}
+
 // SelfFKElem allows a table to have a foreign key to itself. willReference
 // is a placeholder for the column the self-referential foreign key
 // will reference
@@ -206,6 +361,12 @@ func (fk SelfFKElem) Modify(tabular Tabular) error {
 	return fk.FKElem.Modify(table)
 }
 
+// Named sets the name of the self-referential foreign key constraint
+func (fk SelfFKElem) Named(name string) SelfFKElem {
+	fk.FKElem.constraint = name
+	return fk
+}
+
 // SelfForeignKey creates a self-referential foreign key
 func SelfForeignKey(name, ref string, datatypes ...types.Type) SelfFKElem {
 	// Allow the type to be overridden by a single optional type
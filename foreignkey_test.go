@@ -1,6 +1,10 @@
 package sol
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/aodin/sol/types"
+)
 
 func TestForeignKey(t *testing.T) {
 	expect := NewTester(t, &defaultDialect{})
@@ -47,3 +51,66 @@ func TestForeignKey(t *testing.T) {
 		)
 	}
 }
+
+func TestForeignKeyConstraint(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	lines := Table("lines",
+		Column("order_id", types.Integer()),
+		Column("line", types.Integer()),
+		PrimaryKey("order_id", "line"),
+	)
+	shipments := Table("shipments",
+		Column("id", types.Integer()),
+		Column("order_id", types.Integer()),
+		Column("line", types.Integer()),
+		ForeignKeyConstraint(
+			[]string{"order_id", "line"}, lines, nil,
+		).OnDelete(Cascade).Deferrable().Named("shipments_line_fkey"),
+	)
+
+	expect.SQL(
+		shipments.Create(),
+		`CREATE TABLE shipments (
+  id INTEGER,
+  order_id INTEGER,
+  line INTEGER,
+  CONSTRAINT shipments_line_fkey FOREIGN KEY (order_id, line) REFERENCES lines(order_id, line) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED
+);`,
+	)
+
+	// Composite keys are recorded on both tables
+	if len(shipments.ForeignKeys()) != 1 || len(lines.ReferencedBy()) != 1 {
+		t.Fatalf("composite foreign keys should be recorded on both tables")
+	}
+	fk := shipments.ForeignKeys()[0]
+	if !fk.IsComposite() || fk.References() != lines {
+		t.Errorf("unexpected composite foreign key: %v", fk.Names())
+	}
+
+	// Joins use every column of the key
+	expect.SQL(
+		Select(shipments.C("id")).From(shipments).InnerJoin(lines),
+		`SELECT shipments.id FROM shipments INNER JOIN lines ON (shipments.order_id = lines.order_id AND shipments.line = lines.line)`,
+	)
+
+	// Columns can also be referenced explicitly
+	returns := Table("returns",
+		Column("order_id", types.Integer()),
+		ForeignKeyConstraint([]string{"order_id"}, lines, []string{"order_id"}),
+	)
+	expect.SQL(
+		returns.Create(),
+		`CREATE TABLE returns (
+  order_id INTEGER,
+  FOREIGN KEY (order_id) REFERENCES lines(order_id)
+);`,
+	)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("a foreign key constraint with mismatched columns should panic")
+		}
+	}()
+	ForeignKeyConstraint([]string{"order_id"}, lines, nil)
+}
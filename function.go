@@ -1,13 +1,23 @@
 package sol
 
+import "strings"
+
 // TODO Merge with Clause?
 type Operator interface {
-	Wrap(string) string // TODO errors?
+	Wrap(string) string
+}
+
+// FailingOperator is an Operator that may have failed to be created,
+// such as from a value that could not be encoded. Columns return its
+// error when compiled.
+type FailingOperator interface {
+	Operator
+	Err() error
 }
 
 // Function adds a generic function to the column
 func Function(name string, col Columnar) ColumnElem {
-	return col.Column().AddOperator(FuncClause{Name: name})
+	return col.Column().WrapOperator(FuncClause{Name: name})
 }
 
 // Avg returns a column wrapped in the AVG() function
@@ -20,6 +30,28 @@ func Count(col Columnar) ColumnElem {
 	return Function(COUNT, col)
 }
 
+// countAll returns the COUNT(*) selection, which has no table
+func countAll() ColumnElem {
+	return ColumnElem{name: "*"}.WrapOperator(FuncClause{Name: COUNT})
+}
+
+// aggregates are the functions that combine the selected rows into one
+var aggregates = map[string]bool{
+	AVG: true, COUNT: true, MAX: true, MIN: true, STDDEV: true, SUM: true,
+	VARIANCE: true,
+}
+
+// isAggregate returns true if the column is wrapped in an aggregate
+// function, such as MAX()
+func isAggregate(col ColumnElem) bool {
+	for _, op := range col.operators {
+		if fn, ok := op.(FuncClause); ok && aggregates[strings.ToUpper(fn.Name)] {
+			return true
+		}
+	}
+	return false
+}
+
 // Date returns a column wrapped in the DATE() function
 func Date(col Columnar) ColumnElem {
 	return Function(DATE, col)
@@ -28,10 +60,10 @@ func Date(col Columnar) ColumnElem {
 // DatePart returns a column wrapped in the DATE_PART() function
 // TODO This method is unsafe - it should not accept direct user input
 func DatePart(part string, col Columnar) ColumnElem {
-	return col.Column().AddOperator(
-		FuncClause{Name: DATEPART},
-	).AddOperator(
+	return col.Column().WrapOperator(
 		ArrayClause{clauses: []Clause{String(part)}, post: true, sep: ", "},
+	).WrapOperator(
+		FuncClause{Name: DATEPART},
 	)
 }
 
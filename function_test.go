@@ -24,4 +24,20 @@ func TestFunctions(t *testing.T) {
 		Select(DatePart("hour", users.C("created_at")).As("Hour")),
 		`SELECT DATE_PART('hour', users.created_at) AS "Hour" FROM users`,
 	)
+
+	// Functions wrap in the order they are applied
+	expect.SQL(
+		Select(Count(Date(users.C("created_at")))),
+		`SELECT COUNT(DATE(users.created_at)) FROM users`,
+	)
+	expect.SQL(
+		Select(Function(MAX, Date(users.C("created_at")))),
+		`SELECT MAX(DATE(users.created_at)) FROM users`,
+	)
+
+	// Added operators are applied before the existing operators
+	expect.SQL(
+		Select(Date(users.C("created_at")).AddOperator(FuncClause{Name: MAX})),
+		`SELECT DATE(MAX(users.created_at)) FROM users`,
+	)
 }
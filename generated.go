@@ -0,0 +1,157 @@
+package sol
+
+import (
+	"fmt"
+
+	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+// GeneratedType is the type of a column that is computed from an
+// expression of other columns of its row. Generated columns cannot be
+// given values by INSERT or UPDATE statements.
+type GeneratedType struct {
+	datatype  types.Type
+	expr      Clause
+	isVirtual bool
+	isNotNull bool
+	isUnique  bool
+}
+
+var _ types.Type = GeneratedType{}
+
+// Create returns the type followed by its GENERATED ALWAYS AS clause.
+// Like CHECK constraints, the expression's values are compiled as
+// literals and its columns by name only.
+func (t GeneratedType) Create(d dialect.Dialect) (string, error) {
+	if t.datatype == nil || t.expr == nil {
+		return "", fmt.Errorf("sol: generated columns require a type and an expression")
+	}
+	// Options must follow the expression in dialects such as MySQL
+	if optioned, ok := t.datatype.(interface{ Options() []string }); ok && len(optioned.Options()) > 0 {
+		return "", fmt.Errorf("sol: options of generated columns must be set on Generated()")
+	}
+	if defaulter, ok := t.datatype.(types.Defaulter); ok && defaulter.HasDefault() {
+		return "", fmt.Errorf("sol: generated columns cannot have a DEFAULT")
+	}
+
+	compiled, err := t.datatype.Create(d)
+	if err != nil {
+		return "", err
+	}
+	expr, err := t.expr.Compile(literalDialect{Dialect: d}, Params())
+	if err != nil {
+		return "", err
+	}
+	compiled += fmt.Sprintf(" GENERATED ALWAYS AS (%s)", expr)
+	if t.isVirtual {
+		if !dialect.VirtualColumns(d) {
+			return "", fmt.Errorf(
+				"sol: the dialect %T does not support VIRTUAL generated columns", d,
+			)
+		}
+		compiled += " VIRTUAL"
+	} else {
+		compiled += " STORED"
+	}
+	if t.isNotNull {
+		compiled += " NOT NULL"
+	}
+	if t.isUnique {
+		compiled += " UNIQUE"
+	}
+	return compiled, nil
+}
+
+// IsNotNull returns true if the generated column is NOT NULL
+func (t GeneratedType) IsNotNull() bool {
+	return t.isNotNull
+}
+
+// NotNull sets the generated column to NOT NULL
+func (t GeneratedType) NotNull() GeneratedType {
+	t.isNotNull = true
+	return t
+}
+
+// Stored computes the column when its row is written, which is the
+// default and the only kind supported by postgres before version 18
+func (t GeneratedType) Stored() GeneratedType {
+	t.isVirtual = false
+	return t
+}
+
+// Unique sets the generated column to UNIQUE
+func (t GeneratedType) Unique() GeneratedType {
+	t.isUnique = true
+	return t
+}
+
+// Virtual computes the column when it is read. Dialects that do not
+// support virtual columns, such as postgres before version 18, will error.
+func (t GeneratedType) Virtual() GeneratedType {
+	t.isVirtual = true
+	return t
+}
+
+// Generated creates the type of a column computed from the given
+// expression. As with Check, the expression should be built from
+// unbound columns:
+//  price := sol.Column("price", types.Integer())
+//  quantity := sol.Column("quantity", types.Integer())
+//  items := sol.Table("items",
+//      price,
+//      quantity,
+//      sol.Column("total", sol.Generated(
+//          types.Integer(),
+//          sol.BinaryClause{Pre: price, Post: quantity, Sep: " * "},
+//      )),
+//  )
+func Generated(datatype types.Type, expr Clause) GeneratedType {
+	return GeneratedType{datatype: datatype, expr: expr}
+}
+
+// isGenerated returns true if the column's type is generated
+func isGenerated(col ColumnElem) bool {
+	_, ok := col.Type().(GeneratedType)
+	return ok
+}
+
+// generatedColumn returns a GeneratedColumn FieldError for the column
+func generatedColumn(col ColumnElem, clause string) FieldError {
+	e := FieldError{Kind: GeneratedColumn, Column: col.name, Clause: clause}
+	if col.table != nil {
+		e.Table = col.table.name
+	}
+	return e
+}
+
+// generatedValues returns a GeneratedColumn FieldError if any of the keys
+// of the values match a generated column of the table
+func generatedValues(tabular Tabular, values Values, clause string) error {
+	if _, columns := generatedKeys(tabular, values); len(columns) > 0 {
+		return generatedColumn(columns[0], clause)
+	}
+	return nil
+}
+
+// generatedKeys returns the keys of the values that match a generated
+// column of the table, either exactly or after a camel to snake case
+// conversion, and their columns
+func generatedKeys(tabular Tabular, values Values) (keys []string, columns []ColumnElem) {
+	if tabular == nil || tabular.Table() == nil {
+		return
+	}
+	set := tabular.Table().columns
+	for _, key := range values.Keys() {
+		column := set.Get(key)
+		if column.IsInvalid() {
+			column = set.Get(camelToSnake(key))
+		}
+		if column.IsValid() && isGenerated(column) {
+			keys = append(keys, key)
+			columns = append(columns, column)
+		}
+	}
+	return
+}
@@ -0,0 +1,87 @@
+package sol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aodin/sol/types"
+)
+
+func TestGenerated(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	price := Column("price", types.Integer())
+	quantity := Column("quantity", types.Integer())
+	items := Table("items",
+		Column("id", types.Integer()),
+		price,
+		quantity,
+		Column("total", Generated(
+			types.Integer(),
+			BinaryClause{Pre: price, Post: quantity, Sep: " * "},
+		).NotNull()),
+		Column("large", Generated(
+			types.Boolean(), price.GreaterThan(100),
+		).Virtual()),
+	)
+
+	expect.SQL(
+		items.Create(),
+		`CREATE TABLE items (
+  id INTEGER,
+  price INTEGER,
+  quantity INTEGER,
+  total INTEGER GENERATED ALWAYS AS (price * quantity) STORED NOT NULL,
+  large BOOLEAN GENERATED ALWAYS AS (price > 100) VIRTUAL
+);`,
+	)
+
+	// Generated columns are excluded from INSERT and UPDATE
+	expect.SQL(
+		items.Insert(),
+		`INSERT INTO items (id, price, quantity) VALUES ($1, $2, $3)`,
+		nil, nil, nil,
+	)
+	expect.SQL(
+		items.Update(),
+		`UPDATE items SET id = $1, price = $2, quantity = $3`,
+		nil, nil, nil,
+	)
+
+	// Struct fields of generated columns are excluded
+	type item struct {
+		ID    int64
+		Price int64
+		Total int64
+	}
+	expect.SQL(
+		items.Insert().Values(item{ID: 1, Price: 2, Total: 3}),
+		`INSERT INTO items (id, price) VALUES ($1, $2)`,
+		int64(1), int64(2),
+	)
+
+	// But values given for them are errors
+	for _, stmt := range []Executable{
+		items.Insert().Values(Values{"id": 1, "total": 2}),
+		items.Insert().Values([]Values{{"id": 1}, {"Total": 2}}),
+		items.Update().Values(Values{"total": 2}),
+		Insert(items.C("id"), items.C("total")),
+	} {
+		_, err := stmt.Compile(&defaultDialect{}, Params())
+		if !errors.Is(err, ErrGeneratedColumn) {
+			t.Errorf("Generated column values should error with ErrGeneratedColumn, received: %v", err)
+		}
+	}
+
+	// Virtual columns are only created by dialects that support them
+	_, err := Generated(types.Boolean(), price.GreaterThan(100)).Virtual().Create(&plainDialect{})
+	if err == nil {
+		t.Errorf("Virtual generated columns should error in dialects without them")
+	}
+
+	// Options must be set on the generated type
+	_, err = Generated(types.Integer().NotNull(), price).Create(&defaultDialect{})
+	if err == nil {
+		t.Errorf("Generated columns with options on their type should error")
+	}
+}
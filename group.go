@@ -0,0 +1,183 @@
+package sol
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// grouped is a Destination that groups joined rows by a parent table
+type grouped struct {
+	parent Tabular
+	obj    interface{}
+}
+
+// ScanResult implements the Destination interface
+func (g grouped) ScanResult(r Result) error {
+	return r.Group(g.parent, g.obj)
+}
+
+// Grouped creates a Destination that will group joined rows into the
+// given slice of parent structs. See Result.Group for details.
+//  conn.Query(
+//      Users.Select(Contacts).LeftOuterJoin(Contacts, ...),
+//      sol.Grouped(Users, &users),
+//  )
+func Grouped(parent Tabular, obj interface{}) Destination {
+	return grouped{parent: parent, obj: obj}
+}
+
+// Group scans joined rows into the given pointer to a slice of parent
+// structs, which must have a field that is a slice of child structs.
+// Rows are grouped into a single parent by the primary key of the parent
+// table, and each row's child is appended to the parent's slice. Children
+// whose columns are all NULL, such as from a LEFT OUTER JOIN without a
+// match, will be skipped. The table of the children can be set with a
+// tag on the slice field, e.g. `db:"contacts"`.
+func (r Result) Group(parent Tabular, obj interface{}) error {
+	if parent == nil || parent.Table() == nil {
+		return fmt.Errorf("sol: Result.Group requires a parent table")
+	}
+	table := parent.Table()
+
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf(
+			"sol: received a non-slice pointer destination for Result.Group",
+		)
+	}
+	list := value.Elem()
+	elem := list.Type().Elem()
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf(
+			"sol: Result.Group destinations must be a slice of structs",
+		)
+	}
+
+	// Separate the parent's fields from its slice of children
+	var parentFields []Field
+	var children *Field
+	for _, field := range DeepFields(reflect.New(elem).Interface()) {
+		if children == nil && isStructSlice(field.Type.Type) {
+			children = &Field{Type: field.Type}
+			continue
+		}
+		if field.Table == "" {
+			field.Table = table.Name()
+		}
+		parentFields = append(parentFields, field)
+	}
+	if children == nil {
+		return fmt.Errorf(
+			"sol: %s must have a slice of structs to group children into",
+			elem,
+		)
+	}
+	childType := children.Type.Type.Elem()
+	childTable, _ := parseTag(children.Type.Tag.Get(tagLabel))
+	childFields := deepFields(reflect.New(childType).Interface(), childTable)
+
+	// The parent's primary key fields are used to group rows
+	pk := table.PrimaryKey()
+	if len(pk) == 0 {
+		return fmt.Errorf(
+			"sol: table %s must have a primary key to group results",
+			table.Name(),
+		)
+	}
+	keys := make([]Field, len(pk))
+	for i, name := range pk {
+		j := matchField(parentFields, make([]bool, len(parentFields)), "", name)
+		if j == -1 {
+			return fmt.Errorf(
+				"sol: %s has no field for primary key column %s", elem, name,
+			)
+		}
+		keys[i] = parentFields[j]
+	}
+
+	columns, err := r.Columns()
+	if err != nil {
+		return fmt.Errorf("sol: error returning columns from result: %s", err)
+	}
+	fields := append(append([]Field{}, parentFields...), childFields...)
+	aligned := alignIndexes(columns, fields)
+
+	// Index any existing parents by their primary key
+	groups := make(map[string]int)
+	for i := 0; i < list.Len(); i++ {
+		groups[groupKey(list.Index(i), keys)] = i
+	}
+
+	dest := make([]interface{}, len(columns))
+	scanned := make([]reflect.Value, len(columns))
+	for r.Next() {
+		newParent := reflect.New(elem).Elem()
+		for i, j := range aligned {
+			switch {
+			case j == -1:
+				dest[i] = &dest[i] // Discard
+			case j < len(parentFields):
+				dest[i] = fields[j].scanDest(r.dialect,
+					newParent.FieldByIndex(fields[j].Type.Index).Addr().Interface(),
+				)
+			default:
+				// Child columns are scanned into pointers so NULLs
+				// can be detected
+				scanned[i] = reflect.New(reflect.PtrTo(fields[j].Type.Type))
+				dest[i] = fields[j].scanDest(r.dialect, scanned[i].Interface())
+			}
+		}
+
+		if err := r.Scan(dest...); err != nil {
+			return fmt.Errorf("sol: error scanning grouped struct: %s", err)
+		}
+
+		key := groupKey(newParent, keys)
+		index, exists := groups[key]
+		if !exists {
+			list.Set(reflect.Append(list, newParent))
+			index = list.Len() - 1
+			groups[key] = index
+		}
+
+		newChild := reflect.New(childType).Elem()
+		isNull := true
+		for i, j := range aligned {
+			if j < len(parentFields) {
+				continue
+			}
+			value := scanned[i].Elem()
+			if value.IsNil() {
+				continue
+			}
+			isNull = false
+			newChild.FieldByIndex(fields[j].Type.Index).Set(value.Elem())
+		}
+		if isNull {
+			continue
+		}
+
+		slice := list.Index(index).FieldByIndex(children.Type.Index)
+		slice.Set(reflect.Append(slice, newChild))
+	}
+	return r.Err() // Check for delayed scan errors
+}
+
+// groupKey creates a comparable key from the given primary key fields
+func groupKey(elem reflect.Value, keys []Field) string {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i] = elem.FieldByIndex(key.Type.Index).Interface()
+	}
+	return fmt.Sprintf("%#v", values)
+}
+
+// isStructSlice returns true if the given type is a slice of structs that
+// are neither times nor scanners
+func isStructSlice(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	elem := typ.Elem()
+	return elem != timeType && !reflect.PtrTo(elem).Implements(scannerType)
+}
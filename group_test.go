@@ -0,0 +1,98 @@
+package sol
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// rows is a mock Scanner used only for testing. It returns the given
+// values, which may include NULLs.
+type rows struct {
+	columns []string
+	values  [][]interface{}
+	index   int
+}
+
+var _ Scanner = &rows{}
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Columns() ([]string, error) { return r.columns, nil }
+
+func (r *rows) Err() error { return nil }
+
+func (r *rows) Next() bool {
+	r.index += 1
+	return r.index <= len(r.values)
+}
+
+func (r *rows) Scan(dests ...interface{}) error {
+	row := r.values[r.index-1]
+	if len(dests) != len(row) {
+		return fmt.Errorf("Unequal number of scanner destinations")
+	}
+	for i, dest := range dests {
+		v := reflect.ValueOf(dest).Elem()
+		if row[i] == nil {
+			v.Set(reflect.Zero(v.Type()))
+			continue
+		}
+		src := reflect.ValueOf(row[i])
+		if v.Kind() == reflect.Ptr {
+			v.Set(reflect.New(v.Type().Elem()))
+			v = v.Elem()
+		}
+		v.Set(src.Convert(v.Type()))
+	}
+	return nil
+}
+
+func TestResult_Group(t *testing.T) {
+	type userContacts struct {
+		ID       int64
+		Name     string
+		Contacts []contact `db:"contacts"`
+	}
+
+	result := Result{Scanner: &rows{
+		columns: []string{"users__id", "name", "contacts__id", "key"},
+		values: [][]interface{}{
+			{1, "admin", 1, "email"},
+			{2, "client", nil, nil},
+			{1, "admin", 2, "phone"},
+		},
+	}}
+
+	var have []userContacts
+	if err := result.Group(users, &have); err != nil {
+		t.Fatalf("Result.Group should not error: %s", err)
+	}
+
+	want := []userContacts{
+		{
+			ID:   1,
+			Name: "admin",
+			Contacts: []contact{
+				{ID: 1, Key: "email"},
+				{ID: 2, Key: "phone"},
+			},
+		},
+		{ID: 2, Name: "client"},
+	}
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Unequal grouped results: want %+v, have %+v", want, have)
+	}
+
+	// Parents must have a primary key
+	var none []userContacts
+	if err := result.Group(messages, &none); err == nil {
+		t.Errorf("Result.Group should error when the table has no primary key")
+	}
+
+	// Destinations must have a slice of children
+	var flat []user
+	if err := result.Group(users, &flat); err == nil {
+		t.Errorf("Result.Group should error without a slice of children")
+	}
+}
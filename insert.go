@@ -6,8 +6,34 @@ import (
 	"strings"
 
 	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
 )
 
+// DefaultClause is the DEFAULT keyword, which sets a column to its
+// server-side default in INSERT and UPDATE statements
+type DefaultClause struct{}
+
+// Default is the DefaultClause:
+//  users.Insert().Values(sol.Values{"name": "admin", "created": sol.Default})
+var Default = DefaultClause{}
+
+// Compile outputs DEFAULT if the dialect allows it
+func (clause DefaultClause) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	if !dialect.InsertDefaults(d) {
+		return "", FieldError{
+			Kind: UnsupportedValue,
+			Msg:  "sol: the dialect does not support DEFAULT values - insert rows that omit different columns separately",
+		}
+	}
+	return DEFAULT, nil
+}
+
+// hasDefault returns true if the column's type has a server-side default
+func hasDefault(col ColumnElem) bool {
+	defaulter, ok := col.Type().(types.Defaulter)
+	return ok && defaulter.HasDefault()
+}
+
 // InsertStmt is the internal representation of an INSERT statement.
 type InsertStmt struct {
 	Stmt
@@ -31,23 +57,70 @@ func (stmt InsertStmt) Table() Tabular {
 // An error may be returned because of a pre-existing error or because
 // an error occurred during compilation.
 func (stmt InsertStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	names, rows, err := stmt.Rows()
+	if err != nil {
+		return "", err
+	}
+
+	// TODO Bulk insert syntax is dialect specific
+	groups := make([]string, len(rows))
+	for g, row := range rows {
+		group := make([]string, len(row))
+		for i, value := range row {
+			if err = invalidEnum(stmt.columns.Get(names[i]), value); err != nil {
+				return "", err
+			}
+			// Clauses, such as DEFAULT, are compiled as is
+			param, ok := value.(Clause)
+			if !ok {
+				param = NewParam(value)
+			}
+			if group[i], err = param.Compile(d, ps); err != nil {
+				return "", err
+			}
+		}
+		groups[g] = fmt.Sprintf(`(%s)`, strings.Join(group, ", "))
+	}
+
+	compiled := []string{
+		INSERT,
+		INTO,
+		stmt.table.Name(),
+		fmt.Sprintf("(%s)", strings.Join(names, ", ")),
+		VALUES,
+		strings.Join(groups, ", "),
+	}
+	return strings.Join(compiled, WHITESPACE), nil
+}
+
+// Rows returns the names of the columns that will be inserted and the
+// values of each row in the same order as those names. Rows that omit
+// a column with a server-side default, such as structs with omitempty
+// fields, will use Default. An error may be returned because of a
+// pre-existing error or if no columns matched.
+func (stmt InsertStmt) Rows() ([]string, [][]interface{}, error) {
 	// Check for delayed errors
 	if err := stmt.Error(); err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
 	// There must be values, and there must be more than one value in the
 	// first element of the values list - otherwise create nil values
 	// TODO Create a ColumnValuesSet to handle the following?
 	aliases := Aliases{}
-	if len(stmt.valuesList) != 0 && len(stmt.valuesList[0]) != 0 {
+	keys := Values{} // The keys of every row, since rows may omit fields
+	for _, values := range stmt.valuesList {
+		for key := range values {
+			keys[key] = nil
+		}
+	}
+	if len(keys) != 0 {
 		// Be friendly: take the intersection of the Values and the
 		// currently selected columns. The Values keys will be matched
 		// with the precedence:
 		// 1. Exact match
 		// 2. Camel to snake case conversion (case sensitive)
-		// Only the first Values element will be matched
-		for _, key := range stmt.valuesList[0].Keys() {
+		for _, key := range keys.Keys() {
 			column := stmt.columns.Get(key)
 			if column.IsInvalid() {
 				column = stmt.columns.Get(camelToSnake(key))
@@ -68,36 +141,42 @@ func (stmt InsertStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 
 	// No columns? no statement!
 	if len(stmt.columns.order) == 0 {
-		return "", ErrNoColumns
+		return nil, nil, ErrNoColumns
 	}
 
-	// TODO Bulk insert syntax is dialect specific
 	// TODO must all Values must have the same keys?
-	var groups []string
-	if len(stmt.valuesList) != 0 {
-		groups = make([]string, len(stmt.valuesList))
-		for g, values := range stmt.valuesList {
-			group := make([]string, len(stmt.columns.order))
-			for i, column := range stmt.columns.order {
-				param := NewParam(values[aliases[column.Name()]])
-				var err error
-				if group[i], err = param.Compile(d, ps); err != nil {
-					return "", err
-				}
+	names := stmt.columns.Names()
+	rows := make([][]interface{}, len(stmt.valuesList))
+	for r, values := range stmt.valuesList {
+		row := make([]interface{}, len(names))
+		for i, name := range names {
+			value, ok := values[aliases[name]]
+			if !ok && hasDefault(stmt.columns.Get(name)) {
+				value = Default
 			}
-			groups[g] = fmt.Sprintf(`(%s)`, strings.Join(group, ", "))
+			row[i] = value
 		}
+		rows[r] = row
 	}
+	return names, rows, nil
+}
 
-	compiled := []string{
-		INSERT,
-		INTO,
-		stmt.table.Name(),
-		fmt.Sprintf("(%s)", strings.Join(stmt.columns.Names(), ", ")),
-		VALUES,
-		strings.Join(groups, ", "),
-	}
-	return strings.Join(compiled, WHITESPACE), nil
+// Len returns the number of rows of values in the INSERT statement. It
+// implements the Batcher interface.
+func (stmt InsertStmt) Len() int {
+	return len(stmt.valuesList)
+}
+
+// Slice returns a copy of the INSERT statement with only the rows of
+// values from i to j.
+func (stmt InsertStmt) Slice(i, j int) InsertStmt {
+	stmt.valuesList = stmt.valuesList[i:j]
+	return stmt
+}
+
+// Batch implements the Batcher interface. It is an alias for Slice.
+func (stmt InsertStmt) Batch(i, j int) Executable {
+	return stmt.Slice(i, j)
 }
 
 // Values adds parameters to the INSERT statement. Accepted types:
@@ -107,9 +186,10 @@ func (stmt InsertStmt) Values(obj interface{}) InsertStmt {
 	stmt.valuesList = make([]Values, 1)
 
 	// Examine allowed types
-	var unsupported bool
+	var unsupported, explicit bool
 	switch elem.Kind() {
 	case reflect.Map:
+		explicit = true
 		switch converted := obj.(type) {
 		case Values:
 			stmt.valuesList[0] = converted
@@ -121,7 +201,7 @@ func (stmt InsertStmt) Values(obj interface{}) InsertStmt {
 	case reflect.Struct:
 		var err error
 		if stmt.valuesList[0], err = ValuesOf(obj); err != nil {
-			stmt.AddMeta(err.Error())
+			stmt.AddError(inClause(err, INSERT))
 			return stmt
 		}
 	case reflect.Slice:
@@ -137,13 +217,14 @@ func (stmt InsertStmt) Values(obj interface{}) InsertStmt {
 			for i := range stmt.valuesList {
 				obj := elem.Index(i).Interface()
 				if stmt.valuesList[i], err = ValuesOf(obj); err != nil {
-					stmt.AddMeta(err.Error())
+					stmt.AddError(inClause(err, INSERT))
 					return stmt
 				}
 			}
 			break
 		}
 
+		explicit = true
 		switch converted := obj.(type) {
 		// TODO []*Values, *[]*Values are unsupported
 		case []Values:
@@ -157,11 +238,26 @@ func (stmt InsertStmt) Values(obj interface{}) InsertStmt {
 		unsupported = true
 	}
 
+	// Values given for generated columns are errors, while the fields of
+	// structs, which may be selected into, will be silently excluded
+	if explicit {
+		for _, values := range stmt.valuesList {
+			if err := generatedValues(stmt.table, values, INSERT); err != nil {
+				stmt.AddError(err)
+				return stmt
+			}
+		}
+	}
+
 	if unsupported {
-		stmt.AddMeta(
-			"sol: unsupported type %T for inserted values - accepted types: struct, Values, or a slice of either",
-			obj,
-		)
+		stmt.AddError(FieldError{
+			Kind:   UnsupportedValue,
+			Clause: INSERT,
+			Msg: fmt.Sprintf(
+				"sol: unsupported type %T for inserted values - accepted types: struct, Values, or a slice of either",
+				obj,
+			),
+		})
 	}
 	return stmt
 }
@@ -173,10 +269,20 @@ func Insert(selections ...Selectable) (stmt InsertStmt) {
 	columns := []ColumnElem{} // Holds columns until validated
 	for _, selection := range selections {
 		if selection == nil {
-			stmt.AddMeta("sol: received a nil selectable in Insert()")
+			stmt.AddError(FieldError{
+				Kind:   NilSelectable,
+				Clause: INSERT,
+				Msg:    "sol: received a nil selectable in Insert()",
+			})
 			return
 		}
-		columns = append(columns, selection.Columns()...)
+		for _, column := range selection.Columns() {
+			// Generated columns of whole tables are excluded
+			if _, ok := selection.(Columnar); !ok && isGenerated(column) {
+				continue
+			}
+			columns = append(columns, column)
+		}
 	}
 
 	if len(columns) == 0 {
@@ -194,20 +300,31 @@ func Insert(selections ...Selectable) (stmt InsertStmt) {
 
 	for _, column := range columns {
 		if column.IsInvalid() {
-			stmt.AddMeta("sol: column %s does not exist", column.FullName())
+			stmt.AddError(unknownColumn(column, INSERT))
+			continue
+		}
+
+		if isGenerated(column) {
+			stmt.AddError(generatedColumn(column, INSERT))
 			continue
 		}
 
 		if column.Table() != stmt.table {
-			stmt.AddMeta(
-				"sol: all columns in Insert() must belong to table %s",
-				stmt.table.Name(),
-			)
+			stmt.AddError(FieldError{
+				Kind:   WrongTable,
+				Column: column.Name(),
+				Table:  stmt.table.Name(),
+				Clause: INSERT,
+				Msg: fmt.Sprintf(
+					"sol: all columns in Insert() must belong to table %s",
+					stmt.table.Name(),
+				),
+			})
 			continue
 		}
 		var err error
 		if stmt.columns, err = stmt.columns.Add(column); err != nil {
-			stmt.AddMeta(err.Error())
+			stmt.AddError(err)
 		}
 	}
 	return
@@ -1,6 +1,11 @@
 package sol
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/aodin/sol/types"
+)
 
 func TestInsert(t *testing.T) {
 	expect := NewTester(t, &defaultDialect{})
@@ -88,3 +93,47 @@ func TestInsert(t *testing.T) {
 	expect.Error(users.Insert().Values([]struct{}{}))
 	expect.Error(users.Insert().Values(nil))
 }
+
+func TestInsert_defaults(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	items := Table("items",
+		Column("id", types.Integer()),
+		Column("name", types.Varchar()),
+		Column("created", types.Timestamp().Default(
+			types.Expression("CURRENT_TIMESTAMP"),
+		)),
+	)
+	type item struct {
+		ID      int64
+		Name    string    `db:",omitempty"`
+		Created time.Time `db:",omitempty"`
+	}
+
+	// Zero omitempty fields are omitted from the INSERT
+	expect.SQL(
+		items.Insert().Values(item{ID: 1, Name: "a"}),
+		`INSERT INTO items (id, name) VALUES ($1, $2)`,
+		int64(1), "a",
+	)
+
+	// Rows that omit a column with a server-side default use DEFAULT,
+	// while other omitted columns are NULL
+	jan1 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	expect.SQL(
+		items.Insert().Values([]item{{ID: 1, Name: "a"}, {ID: 2, Created: jan1}}),
+		`INSERT INTO items (id, name, created) VALUES ($1, $2, DEFAULT), ($3, $4, $5)`,
+		int64(1), "a", int64(2), nil, jan1,
+	)
+
+	expect.SQL(
+		items.Insert().Values(Values{"id": 1, "created": Default}),
+		`INSERT INTO items (id, created) VALUES ($1, DEFAULT)`,
+		1,
+	)
+
+	// Dialects without DEFAULT in VALUES will error
+	NewTester(t, plainDialect{}).Error(
+		items.Insert().Values([]item{{ID: 1}, {ID: 2, Created: jan1}}),
+	)
+}
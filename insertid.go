@@ -0,0 +1,199 @@
+package sol
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+// returningStmt is an INSERT statement that returns the given column of
+// each inserted row
+type returningStmt struct {
+	InsertStmt
+	column ColumnElem
+}
+
+// Compile outputs the INSERT statement with a RETURNING clause
+func (stmt returningStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	compiled, err := stmt.InsertStmt.Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s %s", compiled, RETURNING, stmt.column.Name()), nil
+}
+
+// Batch implements the Batcher interface
+func (stmt returningStmt) Batch(i, j int) Executable {
+	stmt.InsertStmt = stmt.InsertStmt.Slice(i, j)
+	return stmt
+}
+
+// InsertID inserts the given struct, or slice of structs, into the table
+// and sets the field of the table's auto-increment column, such as
+// types.AutoIncrement, to the id generated for each row. Structs must be
+// given as a pointer. Fields with a zero id are omitted from the INSERT.
+//
+// Dialects with RETURNING, such as postgres, insert the rows in batches,
+// while other dialects insert each row separately and use LastInsertId.
+// All rows are inserted within a single transaction.
+//  err := sol.InsertID(conn, Items, &items)
+func InsertID(conn Conn, table Tabular, obj interface{}) error {
+	if table == nil || table.Table() == nil {
+		return fmt.Errorf("sol: InsertID was given a nil table")
+	}
+	column, ok := autoIncrementColumn(table.Table())
+	if !ok {
+		return fmt.Errorf(
+			"sol: table %s does not have an auto-increment column",
+			table.Table().Name(),
+		)
+	}
+
+	rows, err := insertableRows(obj)
+	if err != nil {
+		return err
+	}
+	ids := make([]Field, len(rows))
+	valuesList := make([]Values, len(rows))
+	for i, row := range rows {
+		if ids[i], ok = idField(row, column.Name()); !ok {
+			return fmt.Errorf(
+				"sol: %s does not have a field for the column %s",
+				row.Type(), column.Name(),
+			)
+		}
+		if valuesList[i], err = ValuesOf(row.Addr().Interface()); err != nil {
+			return err
+		}
+		if isEmptyValue(ids[i].Value) {
+			delete(valuesList[i], ids[i].Name)
+		}
+		// Like structs given to INSERT, generated columns are excluded
+		keys, _ := generatedKeys(table, valuesList[i])
+		valuesList[i] = valuesList[i].Exclude(keys...)
+	}
+
+	stmt := table.Table().Insert().Values(valuesList)
+	return inTransaction(conn, "InsertID", func(exec executer, d dialect.Dialect) error {
+		if dialect.Returning(d) {
+			return insertReturning(exec, d, returningStmt{stmt, column}, ids)
+		}
+		for i := range rows {
+			result, err := execute(exec, d, stmt.Slice(i, i+1))
+			if err != nil {
+				return err
+			}
+			id, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+			if err = setID(ids[i], id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertReturning inserts the rows in batches and sets each returned id
+func insertReturning(exec executer, d dialect.Dialect, stmt returningStmt, ids []Field) error {
+	size, err := BatchSize(d, stmt)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(ids); i += size {
+		j := i + size
+		if j > len(ids) {
+			j = len(ids)
+		}
+		var returned []int64
+		if err = queryAll(exec, d, stmt.Batch(i, j), &returned); err != nil {
+			return err
+		}
+		if len(returned) != j-i {
+			return fmt.Errorf(
+				"sol: InsertID expected %d ids, received %d", j-i, len(returned),
+			)
+		}
+		for k, id := range returned {
+			if err = setID(ids[i+k], id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// autoIncrementColumn returns the table's column with values generated by
+// the database, if one exists
+func autoIncrementColumn(table *TableElem) (ColumnElem, bool) {
+	for _, column := range table.Columns() {
+		incrementer, ok := column.Type().(types.AutoIncrementer)
+		if ok && incrementer.AutoIncrements() {
+			return column, true
+		}
+	}
+	return ColumnElem{}, false
+}
+
+// insertableRows returns the addressable structs of the given pointer to
+// a struct, or slice of structs or pointers to structs
+func insertableRows(obj interface{}) ([]reflect.Value, error) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr && value.Elem().Kind() == reflect.Struct {
+		return []reflect.Value{value.Elem()}, nil
+	}
+	value = reflect.Indirect(value)
+	if value.Kind() == reflect.Slice && value.Len() > 0 {
+		rows := make([]reflect.Value, value.Len())
+		for i := range rows {
+			if rows[i] = reflect.Indirect(value.Index(i)); rows[i].Kind() != reflect.Struct {
+				return nil, unsupportedRows(obj)
+			}
+		}
+		return rows, nil
+	}
+	return nil, unsupportedRows(obj)
+}
+
+// unsupportedRows returns the error of values that InsertID cannot set
+func unsupportedRows(obj interface{}) error {
+	return FieldError{
+		Kind: UnsupportedValue,
+		Msg: fmt.Sprintf(
+			"sol: InsertID requires a pointer to a struct or a non-empty slice of structs, received %T",
+			obj,
+		),
+	}
+}
+
+// idField returns the field of the given struct that matches the column
+func idField(row reflect.Value, column string) (Field, bool) {
+	for _, field := range DeepFields(row.Addr().Interface()) {
+		if field.Name == column || camelToSnake(field.Name) == column {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// setID sets the integer field to the given id
+func setID(field Field, id int64) error {
+	if !field.Value.CanSet() {
+		return fmt.Errorf("sol: cannot set the id of field %s", field.Type.Name)
+	}
+	switch field.Value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.Value.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.Value.SetUint(uint64(id))
+	default:
+		return fmt.Errorf(
+			"sol: cannot set the id of field %s with type %s",
+			field.Type.Name, field.Value.Type(),
+		)
+	}
+	return nil
+}
@@ -9,8 +9,9 @@ import (
 // JoinClause implements a variety of joins
 type JoinClause struct {
 	ArrayClause
-	method string
-	table  Tabular // interface, since there are dialect-specific tables
+	method  string
+	table   Tabular // interface, since there are dialect-specific tables
+	natural bool
 }
 
 // String returns a default string representation of the JoinClause
@@ -23,17 +24,26 @@ func (j JoinClause) String() string {
 func (j JoinClause) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
 	// Ignore clauses if CROSS
 	if j.method == CROSSJOIN {
-		return fmt.Sprintf(`%s %s`, CROSSJOIN, j.table.Name()), nil
+		return fmt.Sprintf(`%s %s`, CROSSJOIN, j.tableName()), nil
 	}
 
-	// If no clauses were given, assume the join is NATURAL
-	if len(j.ArrayClause.clauses) == 0 {
+	if j.natural {
 		return fmt.Sprintf(
-			`NATURAL %s %s`, j.method, j.table.Name(),
+			`NATURAL %s %s`, j.method, j.tableName(),
 		), nil
 	}
 
-	// TODO Pass the joining table and auto-create the condition?
+	// Conditions must be given or inferred from foreign keys with on()
+	if len(j.ArrayClause.clauses) == 0 {
+		return "", FieldError{
+			Table:  j.table.Name(),
+			Clause: j.method,
+			Msg: fmt.Sprintf(
+				"sol: %s %s requires a condition", j.method, j.table.Name(),
+			),
+		}
+	}
+
 	// Compile the clauses of the join statement
 	clauses, err := j.ArrayClause.Compile(d, ps)
 	if err != nil {
@@ -41,6 +51,114 @@ func (j JoinClause) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
 	}
 
 	return fmt.Sprintf(
-		`%s %s ON %s`, j.method, j.table.Name(), clauses,
+		`%s %s ON %s`, j.method, j.tableName(), clauses,
 	), nil
 }
+
+// tableName returns the name of the joined table, including its alias.
+// The alias is read from the underlying table so that dialect specific
+// tables, such as those of postgres, keep their aliases.
+func (j JoinClause) tableName() string {
+	if table := j.table.Table(); table != nil && table.alias != "" {
+		return fmt.Sprintf(`%s AS %s`, table.name, table.alias)
+	}
+	return j.table.Name()
+}
+
+// on returns a copy of the JoinClause with its condition inferred from
+// the one foreign key between its table and the given tables. The join
+// is returned unchanged if it already has a condition or is NATURAL.
+func (j JoinClause) on(tables []Tabular) (JoinClause, error) {
+	if j.method == CROSSJOIN || j.natural || len(j.ArrayClause.clauses) > 0 {
+		return j, nil
+	}
+	joining := j.table.Table()
+
+	// Tables are matched by name so that aliased tables use their
+	// foreign keys, and the condition is created with the aliased columns
+	var conditions []Clause
+	for _, table := range tables {
+		existing := table.Table()
+		if existing == nil || joining == nil {
+			continue
+		}
+		for _, fk := range joining.ForeignKeys() {
+			if fk.references != nil && fk.references.name == existing.name {
+				conditions = append(conditions, joinOn(fk, joining, existing))
+			}
+		}
+		// Self-referential keys of the same table were already added above
+		if existing.reference() == joining.reference() {
+			continue
+		}
+		for _, fk := range existing.ForeignKeys() {
+			if fk.references != nil && fk.references.name == joining.name {
+				conditions = append(conditions, joinOn(fk, existing, joining))
+			}
+		}
+	}
+
+	switch len(conditions) {
+	case 0:
+		return j, FieldError{
+			Table:  j.table.Name(),
+			Clause: j.method,
+			Msg: fmt.Sprintf(
+				"sol: no foreign key exists to join %s - give a condition or use NaturalJoin",
+				j.table.Name(),
+			),
+		}
+	case 1:
+		j.ArrayClause.clauses = conditions
+		return j, nil
+	}
+	return j, FieldError{
+		Table:  j.table.Name(),
+		Clause: j.method,
+		Msg: fmt.Sprintf(
+			"sol: %d foreign keys could be used to join %s - specify one with JoinOn",
+			len(conditions), j.table.Name(),
+		),
+	}
+}
+
+// JoinOn creates the condition that joins the two tables of the given
+// foreign key. It can be given to joins when tables have multiple
+// foreign keys to the same table.
+//  Messages.Select().InnerJoin(Users, sol.JoinOn(fk))
+func JoinOn(fk FKElem) Clause {
+	if fk.table == nil || fk.references == nil {
+		return errClause{FieldError{
+			Column: fk.name,
+			Msg: fmt.Sprintf(
+				"sol: foreign key %s must belong to a table before joining",
+				fk.name,
+			),
+		}}
+	}
+	return joinOn(fk, fk.table, fk.references)
+}
+
+// joinOn creates the condition of the foreign key between the given
+// child and parent tables, either of which may be aliased
+func joinOn(fk FKElem, child, parent *TableElem) Clause {
+	if !fk.IsComposite() {
+		return child.C(fk.name).Equals(parent.C(fk.col.Name()))
+	}
+	refs := fk.ForeignNames()
+	conditions := make([]Clause, len(refs))
+	for i, name := range fk.Names() {
+		conditions[i] = child.C(name).Equals(parent.C(refs[i]))
+	}
+	return AllOf(conditions...)
+}
+
+// errClause is a Clause that always fails to compile with its error
+type errClause struct {
+	err error
+}
+
+// Compile returns the clause's error
+func (clause errClause) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	return "", clause.err
+}
@@ -31,10 +31,44 @@ func TestJoinClause(t *testing.T) {
 	)
 
 	expect.SQL(
-		Select(tableA).InnerJoin(relations),
+		Select(tableA).NaturalJoin(relations),
 		`SELECT a.id, a.value FROM a NATURAL INNER JOIN relations`,
 	)
 
+	// Conditions are inferred from foreign keys in either direction
+	expect.SQL(
+		users.Select().InnerJoin(contacts),
+		`SELECT users.id, users.email, users.name, users.password, users.created_at FROM users INNER JOIN contacts ON contacts.user_id = users.id`,
+	)
+	expect.SQL(
+		Select(contacts.C("id")).LeftOuterJoin(users),
+		`SELECT contacts.id FROM contacts LEFT OUTER JOIN users ON contacts.user_id = users.id`,
+	)
+
+	// Previously joined tables are also matched
+	expect.SQL(
+		Select(contacts.C("id")).InnerJoin(users).InnerJoin(messages),
+		`SELECT contacts.id FROM contacts INNER JOIN users ON contacts.user_id = users.id INNER JOIN messages ON messages.user_id = users.id`,
+	)
+
+	// Tables without a foreign key between them require a condition
+	expect.Error(Select(tableA).InnerJoin(relations))
+
+	// Multiple foreign keys are ambiguous unless one is given with JoinOn
+	transfers := Table("transfers",
+		Column("id", types.Integer()),
+		ForeignKey("from_id", users),
+		ForeignKey("to_id", users),
+	)
+	expect.Error(Select(transfers.C("id")).InnerJoin(users))
+	expect.SQL(
+		Select(transfers.C("id")).InnerJoin(
+			users, JoinOn(transfers.ForeignKeys()[1]),
+		),
+		`SELECT transfers.id FROM transfers INNER JOIN users ON transfers.to_id = users.id`,
+	)
+	expect.Error(Select(users).InnerJoin(messages, JoinOn(FKElem{name: "user_id"})))
+
 	expect.SQL(
 		Select(tableA).LeftOuterJoin(
 			relations,
@@ -48,5 +82,28 @@ func TestJoinClause(t *testing.T) {
 		2,
 	)
 
-	// TODO self join with alias
+	// Self joins require an alias
+	parents := messages.As("parents")
+	expect.SQL(
+		SelectTable(messages, parents.C("id").As("parent")).LeftOuterJoin(
+			parents, messages.C("parent_id").Equals(parents.C("id")),
+		),
+		`SELECT messages.id, messages.user_id, messages.parent_id, messages.text, parents.id AS "parent" FROM messages LEFT OUTER JOIN messages AS parents ON messages.parent_id = parents.id`,
+	)
+
+	// Foreign keys are matched in both directions of a self join
+	expect.Error(Select(messages.C("id")).InnerJoin(parents))
+
+	// The same table can be joined twice
+	senders, receivers := users.As("senders"), users.As("receivers")
+	expect.SQL(
+		Select(transfers.C("id"), senders.C("name"), receivers.C("name")).From(
+			transfers,
+		).InnerJoin(
+			senders, transfers.C("from_id").Equals(senders.C("id")),
+		).InnerJoin(
+			receivers, transfers.C("to_id").Equals(receivers.C("id")),
+		).AliasDuplicates(),
+		`SELECT transfers.id, senders.name AS "senders__name", receivers.name AS "receivers__name" FROM transfers INNER JOIN users AS senders ON transfers.from_id = senders.id INNER JOIN users AS receivers ON transfers.to_id = receivers.id`,
+	)
 }
@@ -0,0 +1,59 @@
+package sol
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonValue encodes a field's value as JSON when it is sent to the
+// database. Nil maps, slices and pointers are sent as NULL.
+type jsonValue struct {
+	value interface{}
+}
+
+var _ driver.Valuer = jsonValue{}
+
+// Value implements the driver.Valuer interface. The JSON is returned as
+// a string, since some drivers send bytes as binary data.
+func (v jsonValue) Value() (driver.Value, error) {
+	switch reflect.ValueOf(v.value).Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		if reflect.ValueOf(v.value).IsNil() {
+			return nil, nil
+		}
+	}
+	b, err := json.Marshal(v.value)
+	if err != nil {
+		return nil, fmt.Errorf("sol: unable to encode value as JSON: %s", err)
+	}
+	return string(b), nil
+}
+
+// jsonScanner decodes a JSON column into the given destination, which
+// must be a pointer. NULLs leave the destination unchanged.
+type jsonScanner struct {
+	dest interface{}
+}
+
+// Scan implements the sql.Scanner interface
+func (s jsonScanner) Scan(src interface{}) error {
+	var b []byte
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		b = value
+	case string:
+		b = []byte(value)
+	default:
+		return fmt.Errorf("sol: unable to decode %T as JSON", src)
+	}
+	if err := json.Unmarshal(b, s.dest); err != nil {
+		return fmt.Errorf("sol: unable to decode JSON: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sol
+
+import (
+	"reflect"
+	"testing"
+)
+
+type preferences struct {
+	Theme  string `json:"theme"`
+	Emails bool   `json:"emails"`
+}
+
+type profile struct {
+	ID          int64
+	Preferences preferences       `db:",json"`
+	Counts      map[string]int64  `db:"counts"`
+	Missing     *preferences      `db:",json"`
+	Labels      map[string]string `db:"-"`
+}
+
+func TestJSONFields(t *testing.T) {
+	fields := DeepFields(profile{})
+	if len(fields) != 4 {
+		t.Fatalf("JSON fields should not be flattened: %d fields", len(fields))
+	}
+	for _, field := range fields[1:] {
+		if !field.IsJSON() {
+			t.Errorf("Field %s should be JSON", field.Name)
+		}
+	}
+	if fields[0].IsJSON() {
+		t.Errorf("Field %s should not be JSON", fields[0].Name)
+	}
+
+	values, err := ValuesOf(profile{
+		ID:          1,
+		Preferences: preferences{Theme: "dark"},
+		Counts:      map[string]int64{"a": 1},
+	})
+	if err != nil {
+		t.Fatalf("ValuesOf should not error: %s", err)
+	}
+	for key, want := range map[string]interface{}{
+		"ID":          int64(1),
+		"Preferences": `{"theme":"dark","emails":false}`,
+		"counts":      `{"a":1}`,
+		"Missing":     nil,
+	} {
+		have := values[key]
+		var err error
+		if valuer, ok := have.(jsonValue); ok {
+			have, err = valuer.Value()
+		}
+		if err != nil {
+			t.Errorf("Value of %s should not error: %s", key, err)
+		}
+		if have != want {
+			t.Errorf("Unexpected value of %s: want %v, have %v", key, want, have)
+		}
+	}
+
+	var dest profile
+	scanner := jsonScanner{&dest.Preferences}
+	if err := scanner.Scan([]byte(`{"theme":"light","emails":true}`)); err != nil {
+		t.Fatalf("Scan should not error: %s", err)
+	}
+	if want := (preferences{Theme: "light", Emails: true}); dest.Preferences != want {
+		t.Errorf("Unexpected scanned value: %+v", dest.Preferences)
+	}
+	if err := (jsonScanner{&dest.Counts}).Scan(`{"b":2}`); err != nil {
+		t.Fatalf("Scan should not error: %s", err)
+	}
+	if !reflect.DeepEqual(dest.Counts, map[string]int64{"b": 2}) {
+		t.Errorf("Unexpected scanned value: %v", dest.Counts)
+	}
+	if err := (jsonScanner{&dest.Missing}).Scan(nil); err != nil || dest.Missing != nil {
+		t.Errorf("NULL should be scanned as nil: %v, %v", dest.Missing, err)
+	}
+	if err := (jsonScanner{&dest.Counts}).Scan(`[`); err == nil {
+		t.Errorf("Invalid JSON should error")
+	}
+}
@@ -0,0 +1,291 @@
+package sol
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aodin/sol/dialect"
+)
+
+func init() {
+	// Cursor values are encoded as interfaces
+	gob.Register(time.Time{})
+}
+
+// rowComparison compares the given columns to the given values as row
+// values, such as (a, b) > ($1, $2)
+type rowComparison struct {
+	columns []ColumnElem
+	op      string
+	values  []interface{}
+}
+
+var _ Clause = rowComparison{}
+
+// Compile returns the row comparison as a compiled string using
+// the given Dialect - possibly with an error. Any parameters will
+// be appended to the given Parameters.
+func (c rowComparison) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	columns := make([]string, len(c.columns))
+	params := make([]string, len(c.values))
+	var err error
+	for i, column := range c.columns {
+		if columns[i], err = column.Compile(d, ps); err != nil {
+			return "", err
+		}
+	}
+	for i, value := range c.values {
+		if params[i], err = NewParam(value).Compile(d, ps); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf(
+		"(%s) %s (%s)",
+		strings.Join(columns, ", "), c.op, strings.Join(params, ", "),
+	), nil
+}
+
+// After sets the values of the last row of the previous page for keyset
+// pagination. The values must be given in the order of the statement's
+// ORDER BY columns, which should uniquely order the rows. The statement
+// will only return the rows that come after it. Since dialects order
+// NULLs differently, columns that are not NOT NULL or part of the primary
+// key must be ordered with an explicit NullsFirst or NullsLast.
+//  Users.Select().OrderBy(Users.C("name"), Users.C("id")).After("admin", 1)
+func (stmt SelectStmt) After(values ...interface{}) SelectStmt {
+	stmt.after = values
+	return stmt
+}
+
+// AfterCursor sets the last row of the previous page from the given cursor,
+// which should be created by Cursor. An empty cursor is the first page.
+func (stmt SelectStmt) AfterCursor(cursor string) SelectStmt {
+	if cursor == "" {
+		stmt.after = nil
+		return stmt
+	}
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		stmt.AddError(err)
+		return stmt
+	}
+	return stmt.After(values...)
+}
+
+// Cursor returns an opaque cursor of the given row for use with
+// AfterCursor. The row can be a struct or Values and must include the
+// statement's ORDER BY columns. Cursors are encoded, but not signed or
+// encrypted: clients can read them and give any values in their place.
+//  cursor, err := stmt.Cursor(users[len(users)-1])
+func (stmt SelectStmt) Cursor(row interface{}) (string, error) {
+	if len(stmt.orderBy) == 0 {
+		return "", fmt.Errorf("sol: cursors require an ORDER BY")
+	}
+	names := make([]string, len(stmt.orderBy))
+	for i, ord := range stmt.orderBy {
+		column := ord.inner.Column()
+		names[i] = column.Name()
+		if column.Alias() != "" {
+			names[i] = column.Alias()
+		}
+	}
+
+	values := make([]interface{}, len(names))
+	switch row := row.(type) {
+	case Values:
+		for i, name := range names {
+			value, exists := row[name]
+			if !exists {
+				return "", fmt.Errorf(
+					"sol: Values have no key for ORDER BY column %s", name,
+				)
+			}
+			values[i] = value
+		}
+	default:
+		elem := reflect.Indirect(reflect.ValueOf(row))
+		if elem.Kind() != reflect.Struct {
+			return "", fmt.Errorf(
+				"sol: Cursor must be given a struct or Values, received %T",
+				row,
+			)
+		}
+		fields := DeepFields(elem.Interface())
+		for i, j := range alignIndexes(names, fields) {
+			if j == -1 {
+				return "", fmt.Errorf(
+					"sol: %s has no field for ORDER BY column %s",
+					elem.Type(), names[i],
+				)
+			}
+			value, isNull := keyValue(elem.FieldByIndex(fields[j].Type.Index))
+			if !isNull {
+				values[i] = value
+			}
+		}
+	}
+	return EncodeCursor(values...)
+}
+
+// EncodeCursor encodes the given values as an opaque, URL safe cursor.
+// Values are converted to their driver types, e.g. int64 or time.Time.
+// Since cursors are not signed, decoded values should be treated as
+// untrusted input.
+func EncodeCursor(values ...interface{}) (string, error) {
+	converted := make([]interface{}, len(values))
+	var err error
+	for i, value := range values {
+		converted[i], err = driver.DefaultParameterConverter.ConvertValue(value)
+		if err != nil {
+			return "", fmt.Errorf("sol: unable to encode cursor: %s", err)
+		}
+	}
+	var buffer bytes.Buffer
+	if err = gob.NewEncoder(&buffer).Encode(converted); err != nil {
+		return "", fmt.Errorf("sol: unable to encode cursor: %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// DecodeCursor decodes the values of a cursor created by EncodeCursor
+func DecodeCursor(cursor string) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("sol: invalid cursor: %s", err)
+	}
+	var values []interface{}
+	if err = gob.NewDecoder(bytes.NewReader(b)).Decode(&values); err != nil {
+		return nil, fmt.Errorf("sol: invalid cursor: %s", err)
+	}
+	return values, nil
+}
+
+// keyset creates the condition that selects the rows after the
+// statement's keyset values. Dialects that support row values will use
+// a row comparison when every column has the same direction, otherwise
+// the comparison is expanded, e.g. a > $1 OR (a = $2 AND b > $3).
+func (stmt SelectStmt) keyset(d dialect.Dialect) (Clause, error) {
+	if len(stmt.orderBy) == 0 {
+		return nil, FieldError{
+			Clause: WHERE,
+			Msg:    "sol: keyset pagination requires an ORDER BY",
+		}
+	}
+	if len(stmt.after) != len(stmt.orderBy) {
+		return nil, FieldError{
+			Clause: WHERE,
+			Msg: fmt.Sprintf(
+				"sol: keyset pagination was given %d values for %d ORDER BY columns",
+				len(stmt.after), len(stmt.orderBy),
+			),
+		}
+	}
+
+	columns := make([]ColumnElem, len(stmt.orderBy))
+	for i, ord := range stmt.orderBy {
+		columns[i] = ord.inner.Column()
+		// Since dialects order NULLs differently, nullable columns must
+		// order them explicitly
+		if ord.nullsFirst || ord.nullsLast {
+			continue
+		}
+		if stmt.after[i] == nil {
+			return nil, FieldError{
+				Column: columns[i].Name(),
+				Clause: WHERE,
+				Msg: fmt.Sprintf(
+					"sol: keyset pagination was given NULL for column %s without NullsFirst or NullsLast",
+					columns[i].Name(),
+				),
+			}
+		}
+		if !columns[i].isNotNull() {
+			return nil, FieldError{
+				Column: columns[i].Name(),
+				Clause: WHERE,
+				Msg: fmt.Sprintf(
+					"sol: keyset pagination requires NullsFirst or NullsLast for the nullable column %s",
+					columns[i].Name(),
+				),
+			}
+		}
+	}
+
+	if dialect.RowValues(d) && canCompareRows(stmt.orderBy, stmt.after) {
+		op := GreaterThan
+		if stmt.orderBy[0].desc {
+			op = LessThan
+		}
+		return rowComparison{columns: columns, op: op, values: stmt.after}, nil
+	}
+
+	var disjuncts []Clause
+	for i, ord := range stmt.orderBy {
+		after := afterValue(ord, columns[i], stmt.after[i])
+		if after == nil {
+			continue // No rows follow NULLs that are last
+		}
+		conditions := make([]Clause, 0, i+1)
+		for j := 0; j < i; j++ {
+			if stmt.after[j] == nil {
+				conditions = append(conditions, columns[j].IsNull())
+			} else {
+				conditions = append(conditions, columns[j].Equals(stmt.after[j]))
+			}
+		}
+		conditions = append(conditions, after)
+		if len(conditions) == 1 {
+			disjuncts = append(disjuncts, after)
+		} else {
+			disjuncts = append(disjuncts, AllOf(conditions...))
+		}
+	}
+
+	switch len(disjuncts) {
+	case 0:
+		return UnaryClause{Sep: "1 = 0"}, nil
+	case 1:
+		return disjuncts[0], nil
+	}
+	return AnyOf(disjuncts...), nil
+}
+
+// afterValue creates the condition for the values of a single column that
+// come after the given value, or nil if there are none
+func afterValue(ord OrderedColumn, column ColumnElem, value interface{}) Clause {
+	if value == nil {
+		if ord.nullsFirst {
+			return column.IsNotNull()
+		}
+		return nil
+	}
+	var after Clause = column.GreaterThan(value)
+	if ord.desc {
+		after = column.LessThan(value)
+	}
+	if ord.nullsLast {
+		return AnyOf(after, column.IsNull())
+	}
+	return after
+}
+
+// canCompareRows returns true if the ordering and values can be compared
+// as row values: there must be multiple columns of the same direction,
+// no NULL values, and no NULLs ordered last
+func canCompareRows(orderBy []OrderedColumn, values []interface{}) bool {
+	if len(orderBy) < 2 {
+		return false
+	}
+	for i, ord := range orderBy {
+		if ord.desc != orderBy[0].desc || ord.nullsLast || values[i] == nil {
+			return false
+		}
+	}
+	return true
+}
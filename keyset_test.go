@@ -0,0 +1,151 @@
+package sol
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// rowDialect is a test dialect that supports row values
+type rowDialect struct {
+	defaultDialect
+}
+
+func (d rowDialect) RowValues() bool {
+	return true
+}
+
+func TestSelectStmt_After(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+	rows := NewTester(t, rowDialect{})
+
+	byName := Select(users.C("id")).OrderBy(users.C("name"), users.C("id"))
+
+	expect.SQL(
+		byName.After("admin", 2),
+		`SELECT users.id FROM users WHERE (users.name > $1 OR (users.name = $2 AND users.id > $3)) ORDER BY users.name, users.id`,
+		"admin", "admin", 2,
+	)
+	rows.SQL(
+		byName.After("admin", 2),
+		`SELECT users.id FROM users WHERE (users.name, users.id) > ($1, $2) ORDER BY users.name, users.id`,
+		"admin", 2,
+	)
+
+	// Existing conditions are kept
+	rows.SQL(
+		byName.Where(users.C("email").Equals("admin@example.com")).After("admin", 2),
+		`SELECT users.id FROM users WHERE (users.email = $1 AND (users.name, users.id) > ($2, $3)) ORDER BY users.name, users.id`,
+		"admin@example.com", "admin", 2,
+	)
+
+	// Descending columns
+	rows.SQL(
+		Select(users.C("id")).OrderBy(
+			users.C("created_at").Desc().NullsFirst(), users.C("id").Desc(),
+		).After(3, 2),
+		`SELECT users.id FROM users WHERE (users.created_at, users.id) < ($1, $2) ORDER BY users.created_at DESC NULLS FIRST, users.id DESC`,
+		3, 2,
+	)
+
+	// Mixed directions cannot use row values
+	rows.SQL(
+		Select(users.C("id")).OrderBy(
+			users.C("name").Desc(), users.C("id"),
+		).After("admin", 2),
+		`SELECT users.id FROM users WHERE (users.name < $1 OR (users.name = $2 AND users.id > $3)) ORDER BY users.name DESC, users.id`,
+		"admin", "admin", 2,
+	)
+
+	// NULLs ordered last follow every value and are followed by nothing
+	nullsLast := Select(users.C("id")).OrderBy(
+		users.C("password").NullsLast(), users.C("id"),
+	)
+	rows.SQL(
+		nullsLast.After("secret", 2),
+		`SELECT users.id FROM users WHERE ((users.password > $1 OR users.password IS NULL) OR (users.password = $2 AND users.id > $3)) ORDER BY users.password NULLS LAST, users.id`,
+		"secret", "secret", 2,
+	)
+	rows.SQL(
+		nullsLast.After(nil, 2),
+		`SELECT users.id FROM users WHERE (users.password IS NULL AND users.id > $1) ORDER BY users.password NULLS LAST, users.id`,
+		2,
+	)
+
+	// NULLs ordered first are followed by every value
+	expect.SQL(
+		Select(users.C("id")).OrderBy(
+			users.C("password").NullsFirst(), users.C("id"),
+		).After(nil, 2),
+		`SELECT users.id FROM users WHERE (users.password IS NOT NULL OR (users.password IS NULL AND users.id > $1)) ORDER BY users.password NULLS FIRST, users.id`,
+		2,
+	)
+
+	// Single columns
+	expect.SQL(
+		Select(users.C("id")).OrderBy(users.C("id")).After(2),
+		`SELECT users.id FROM users WHERE users.id > $1 ORDER BY users.id`,
+		2,
+	)
+	expect.SQL(
+		Select(users.C("id")).OrderBy(users.C("id").NullsLast()).After(nil),
+		`SELECT users.id FROM users WHERE 1 = 0 ORDER BY users.id NULLS LAST`,
+	)
+
+	// Errors
+	expect.Error(Select(users.C("id")).After(2))
+	expect.Error(byName.After(2))
+	expect.Error(byName.After(nil, 2))
+	expect.Error(Select(users.C("id")).OrderBy(users.C("password")).After("secret"))
+	expect.Error(byName.AfterCursor("not a cursor"))
+}
+
+func TestSelectStmt_Cursor(t *testing.T) {
+	stmt := Select(users.C("id")).OrderBy(
+		users.C("created_at").Desc().NullsLast(), users.C("id").Desc(),
+	)
+	created := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := user{ID: 2, Name: "admin", CreatedAt: created}
+
+	cursor, err := stmt.Cursor(&last)
+	if err != nil {
+		t.Fatalf("Cursor should not error: %s", err)
+	}
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor should not error: %s", err)
+	}
+	want := []interface{}{created, int64(2)}
+	if !reflect.DeepEqual(want, values) {
+		t.Errorf("Unexpected cursor values: want %v, have %v", want, values)
+	}
+
+	expect := NewTester(t, &defaultDialect{})
+	expect.SQL(
+		stmt.AfterCursor(cursor),
+		`SELECT users.id FROM users WHERE ((users.created_at < $1 OR users.created_at IS NULL) OR (users.created_at = $2 AND users.id < $3)) ORDER BY users.created_at DESC NULLS LAST, users.id DESC`,
+		created, created, int64(2),
+	)
+
+	// An empty cursor is the first page
+	expect.SQL(
+		stmt.AfterCursor(""),
+		`SELECT users.id FROM users ORDER BY users.created_at DESC NULLS LAST, users.id DESC`,
+	)
+
+	// Values and NULLs
+	cursor, err = stmt.Cursor(Values{"created_at": nil, "id": 1})
+	if err != nil {
+		t.Fatalf("Cursor should not error: %s", err)
+	}
+	if values, _ = DecodeCursor(cursor); !reflect.DeepEqual(values, []interface{}{nil, int64(1)}) {
+		t.Errorf("Unexpected cursor values: %v", values)
+	}
+
+	if _, err = stmt.Cursor(Values{"id": 1}); err == nil {
+		t.Errorf("Cursor should error when a column is missing")
+	}
+	if _, err = Select(users.C("id")).Cursor(last); err == nil {
+		t.Errorf("Cursor should error without an ORDER BY")
+	}
+}
@@ -0,0 +1,293 @@
+package sol
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// dialecter is implemented by connections that know their dialect, such
+// as those created by sol.Open
+type dialecter interface {
+	Dialect() dialect.Dialect
+}
+
+// LoadChildren selects every row of the foreign key's table that references
+// one of the given parents using SELECT ... WHERE ... IN and appends each
+// row to the slice field of its parent. The keys are split across as many
+// statements as needed to keep within the dialect's parameter limit. Parents must be given
+// as a pointer to a slice of structs. The slice field of children must
+// either be tagged with the name of the foreign key's table, such as
+// `db:"contacts"`, or have a name that converts to it, such as Contacts.
+//  err := sol.LoadChildren(conn, Contacts.ForeignKeys()[0], &users)
+func LoadChildren(conn Conn, fk FKElem, parents interface{}) error {
+	if fk.table == nil || fk.references == nil {
+		return fmt.Errorf(
+			"sol: foreign key %s must belong to a table before loading", fk.name,
+		)
+	}
+	if fk.IsComposite() {
+		return fmt.Errorf(
+			"sol: composite foreign key (%s) cannot be loaded",
+			strings.Join(fk.Names(), ", "),
+		)
+	}
+	list, err := structSlice(parents, "LoadChildren")
+	if err != nil {
+		return err
+	}
+	elem := list.Type().Elem()
+
+	field, ok := relationField(elem, fk.table.Name(), reflect.Slice)
+	if !ok {
+		return fmt.Errorf(
+			"sol: %s has no slice field for the children of table %s",
+			elem, fk.table.Name(),
+		)
+	}
+	childType := field.Type.Type.Elem()
+
+	// Collect the referenced values of each parent
+	parentKey, err := keyField(elem, fk.col.Name())
+	if err != nil {
+		return err
+	}
+	keys := make(map[string][]int)
+	var args []interface{}
+	for i := 0; i < list.Len(); i++ {
+		value, isNull := keyValue(list.Index(i).FieldByIndex(parentKey.Type.Index))
+		if isNull {
+			continue
+		}
+		key := fmt.Sprint(value)
+		if _, exists := keys[key]; !exists {
+			args = append(args, value)
+		}
+		keys[key] = append(keys[key], i)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	rows, err := selectIn(conn, fk.table, fk.name, args, childType)
+	if err != nil {
+		return err
+	}
+
+	// Attach each child to every parent it references
+	childKey, err := keyField(childType, fk.name)
+	if err != nil {
+		return err
+	}
+	for c := 0; c < rows.Len(); c++ {
+		child := rows.Index(c)
+		value, isNull := keyValue(child.FieldByIndex(childKey.Type.Index))
+		if isNull {
+			continue
+		}
+		for _, i := range keys[fmt.Sprint(value)] {
+			slice := list.Index(i).FieldByIndex(field.Type.Index)
+			slice.Set(reflect.Append(slice, child))
+		}
+	}
+	return nil
+}
+
+// LoadParents is the inverse of LoadChildren: it selects every row of the
+// table referenced by the foreign key that is referenced by one of the given
+// children using SELECT ... WHERE ... IN, which is split like that of
+// LoadChildren, and sets the struct field of each child. Children must be given as a pointer to a slice of structs.
+// The parent field may be a struct or pointer to a struct and must either
+// be tagged with the name of the referenced table, such as `db:"users"`,
+// or have a name that converts to it, such as Users.
+//  err := sol.LoadParents(conn, Contacts.ForeignKeys()[0], &contacts)
+func LoadParents(conn Conn, fk FKElem, children interface{}) error {
+	if fk.table == nil || fk.references == nil {
+		return fmt.Errorf(
+			"sol: foreign key %s must belong to a table before loading", fk.name,
+		)
+	}
+	if fk.IsComposite() {
+		return fmt.Errorf(
+			"sol: composite foreign key (%s) cannot be loaded",
+			strings.Join(fk.Names(), ", "),
+		)
+	}
+	list, err := structSlice(children, "LoadParents")
+	if err != nil {
+		return err
+	}
+	elem := list.Type().Elem()
+
+	field, ok := relationField(elem, fk.references.Name(), reflect.Struct)
+	if !ok {
+		return fmt.Errorf(
+			"sol: %s has no struct field for the parent table %s",
+			elem, fk.references.Name(),
+		)
+	}
+	parentType := field.Type.Type
+	if parentType.Kind() == reflect.Ptr {
+		parentType = parentType.Elem()
+	}
+
+	// Collect the foreign key values of each child
+	childKey, err := keyField(elem, fk.name)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string][]int)
+	var args []interface{}
+	for i := 0; i < list.Len(); i++ {
+		value, isNull := keyValue(list.Index(i).FieldByIndex(childKey.Type.Index))
+		if isNull {
+			continue
+		}
+		key := fmt.Sprint(value)
+		if _, exists := keys[key]; !exists {
+			args = append(args, value)
+		}
+		keys[key] = append(keys[key], i)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	rows, err := selectIn(conn, fk.references, fk.col.Name(), args, parentType)
+	if err != nil {
+		return err
+	}
+
+	// Set the parent of every child that references it
+	parentKey, err := keyField(parentType, fk.col.Name())
+	if err != nil {
+		return err
+	}
+	for p := 0; p < rows.Len(); p++ {
+		parent := rows.Index(p)
+		value, _ := keyValue(parent.FieldByIndex(parentKey.Type.Index))
+		for _, i := range keys[fmt.Sprint(value)] {
+			dest := list.Index(i).FieldByIndex(field.Type.Index)
+			if dest.Kind() == reflect.Ptr {
+				ptr := reflect.New(parentType)
+				ptr.Elem().Set(parent)
+				dest.Set(ptr)
+			} else {
+				dest.Set(parent)
+			}
+		}
+	}
+	return nil
+}
+
+// selectIn selects every row of the table whose column is one of the
+// given values into a slice of the given struct type. If the connection's
+// dialect has a parameter limit, the values are split into chunks of at
+// most that size and the rows of each chunk are merged.
+func selectIn(conn Conn, table *TableElem, column string, args []interface{}, typ reflect.Type) (reflect.Value, error) {
+	size := len(args)
+	if withDialect, ok := conn.(dialecter); ok {
+		if limit := dialect.MaxParams(withDialect.Dialect()); limit > 0 && limit < size {
+			size = limit
+		}
+	}
+
+	rows := reflect.MakeSlice(reflect.SliceOf(typ), 0, len(args))
+	for start := 0; start < len(args); start += size {
+		end := start + size
+		if end > len(args) {
+			end = len(args)
+		}
+		chunk := reflect.New(reflect.SliceOf(typ))
+		stmt := table.Select().Where(table.C(column).In(args[start:end]))
+		if err := conn.Query(stmt, chunk.Interface()); err != nil {
+			return rows, err
+		}
+		rows = reflect.AppendSlice(rows, chunk.Elem())
+	}
+	return rows, nil
+}
+
+// structSlice returns the slice that the given pointer references. The
+// slice must have an element type of struct.
+func structSlice(obj interface{}, method string) (reflect.Value, error) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return value, fmt.Errorf(
+			"sol: %s must be given a pointer to a slice, received %T",
+			method, obj,
+		)
+	}
+	list := value.Elem()
+	if list.Type().Elem().Kind() != reflect.Struct {
+		return list, fmt.Errorf(
+			"sol: %s must be given a slice of structs, received %T",
+			method, obj,
+		)
+	}
+	return list, nil
+}
+
+// relationField returns the top level field of the given struct type that
+// holds the related rows of the given table. Slice fields must have an
+// element type of struct and struct fields may also be pointers.
+func relationField(typ reflect.Type, table string, kind reflect.Kind) (Field, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := NewField(reflect.Value{}, typ.Field(i))
+		if field.IsIgnorable() || field.Type.PkgPath != "" {
+			continue
+		}
+		if field.Name != table && camelToSnake(field.Name) != table {
+			continue
+		}
+
+		ftype := field.Type.Type
+		if kind == reflect.Struct && ftype.Kind() == reflect.Ptr {
+			ftype = ftype.Elem()
+		}
+		if ftype.Kind() != kind {
+			continue
+		}
+		if kind == reflect.Slice && ftype.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		return field, true
+	}
+	return Field{}, false
+}
+
+// keyField returns the field of the given struct type that matches the
+// given column name
+func keyField(typ reflect.Type, column string) (Field, error) {
+	fields := DeepFields(reflect.New(typ).Interface())
+	j := matchField(fields, make([]bool, len(fields)), "", column)
+	if j == -1 {
+		return Field{}, fmt.Errorf(
+			"sol: %s has no field for column %s", typ, column,
+		)
+	}
+	return fields[j], nil
+}
+
+// keyValue returns the underlying value of a key field and whether it
+// is NULL. Pointers will be dereferenced and driver.Valuer types, such
+// as sql.NullInt64, will be converted to their value.
+func keyValue(v reflect.Value) (interface{}, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return nil, true
+		}
+		return value, false
+	}
+	return v.Interface(), false
+}
@@ -0,0 +1,161 @@
+package sol
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// fakeConn is a mock Conn used only for testing. It records the compiled
+// statements and returns the given rows for each query.
+type fakeConn struct {
+	dialect dialect.Dialect
+	queries []string
+	results []*rows
+}
+
+var _ Conn = &fakeConn{}
+
+func (c *fakeConn) Begin() (TX, error) { return nil, fmt.Errorf("unsupported") }
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Dialect() dialect.Dialect {
+	if c.dialect == nil {
+		return &defaultDialect{}
+	}
+	return c.dialect
+}
+
+func (c *fakeConn) Query(stmt Executable, dest ...interface{}) error {
+	c.queries = append(c.queries, c.String(stmt))
+	result := Result{Scanner: c.results[0]}
+	c.results = c.results[1:]
+	return result.All(dest[0])
+}
+
+func (c *fakeConn) String(stmt Executable) string {
+	compiled, err := stmt.Compile(c.Dialect(), Params())
+	if err != nil {
+		return err.Error()
+	}
+	return compiled
+}
+
+func TestLoadChildren(t *testing.T) {
+	type userContacts struct {
+		ID       int64
+		Name     string
+		Contacts []contact
+	}
+
+	conn := &fakeConn{results: []*rows{{
+		columns: []string{"id", "user_id", "key", "value"},
+		values: [][]interface{}{
+			{1, 2, "email", "client@example.com"},
+			{2, 1, "email", "admin@example.com"},
+			{3, 2, "phone", "555-5555"},
+		},
+	}}}
+
+	have := []userContacts{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 1}}
+	if err := LoadChildren(conn, contacts.ForeignKeys()[0], &have); err != nil {
+		t.Fatalf("LoadChildren should not error: %s", err)
+	}
+
+	wantSQL := `SELECT contacts.id, contacts.user_id, contacts.key, contacts.value FROM contacts WHERE contacts.user_id IN ($1, $2, $3)`
+	if len(conn.queries) != 1 || conn.queries[0] != wantSQL {
+		t.Errorf("Unexpected queries: %v", conn.queries)
+	}
+
+	admin := []contact{{ID: 2, UserID: 1, Key: "email", Value: "admin@example.com"}}
+	want := []userContacts{
+		{ID: 1, Contacts: admin},
+		{ID: 2, Contacts: []contact{
+			{ID: 1, UserID: 2, Key: "email", Value: "client@example.com"},
+			{ID: 3, UserID: 2, Key: "phone", Value: "555-5555"},
+		}},
+		{ID: 3},
+		{ID: 1, Contacts: admin},
+	}
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Unequal loaded children: want %+v, have %+v", want, have)
+	}
+
+	// Keys are split by the parameter limit of the dialect
+	conn = &fakeConn{
+		dialect: limitedDialect{limit: 2},
+		results: []*rows{
+			{
+				columns: []string{"id", "user_id", "key", "value"},
+				values:  [][]interface{}{{1, 2, "email", "client@example.com"}},
+			},
+			{
+				columns: []string{"id", "user_id", "key", "value"},
+				values:  [][]interface{}{{4, 3, "email", "guest@example.com"}},
+			},
+		},
+	}
+	have = []userContacts{{ID: 1}, {ID: 2}, {ID: 3}}
+	if err := LoadChildren(conn, contacts.ForeignKeys()[0], &have); err != nil {
+		t.Fatalf("LoadChildren should not error: %s", err)
+	}
+	wantQueries := []string{
+		`SELECT contacts.id, contacts.user_id, contacts.key, contacts.value FROM contacts WHERE contacts.user_id IN ($1, $2)`,
+		`SELECT contacts.id, contacts.user_id, contacts.key, contacts.value FROM contacts WHERE contacts.user_id IN ($1)`,
+	}
+	if !reflect.DeepEqual(wantQueries, conn.queries) {
+		t.Errorf("Unexpected queries: %v", conn.queries)
+	}
+	want = []userContacts{
+		{ID: 1},
+		{ID: 2, Contacts: []contact{
+			{ID: 1, UserID: 2, Key: "email", Value: "client@example.com"},
+		}},
+		{ID: 3, Contacts: []contact{
+			{ID: 4, UserID: 3, Key: "email", Value: "guest@example.com"},
+		}},
+	}
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Unequal loaded children: want %+v, have %+v", want, have)
+	}
+
+	// The destination must have a field for the children
+	var flat []user
+	if err := LoadChildren(conn, contacts.ForeignKeys()[0], &flat); err == nil {
+		t.Errorf("LoadChildren should error without a slice of children")
+	}
+}
+
+func TestLoadParents(t *testing.T) {
+	type contactUser struct {
+		ID     int64
+		UserID int64
+		User   *user `db:"users"`
+	}
+
+	conn := &fakeConn{results: []*rows{{
+		columns: []string{"id", "email", "name"},
+		values: [][]interface{}{
+			{1, "admin@example.com", "admin"},
+		},
+	}}}
+
+	have := []contactUser{{ID: 1, UserID: 1}, {ID: 2, UserID: 1}}
+	if err := LoadParents(conn, contacts.ForeignKeys()[0], &have); err != nil {
+		t.Fatalf("LoadParents should not error: %s", err)
+	}
+
+	wantSQL := `SELECT users.id, users.email, users.name, users.password, users.created_at FROM users WHERE users.id IN ($1)`
+	if len(conn.queries) != 1 || conn.queries[0] != wantSQL {
+		t.Errorf("Unexpected queries: %v", conn.queries)
+	}
+
+	for _, child := range have {
+		if child.User == nil || child.User.Name != "admin" {
+			t.Errorf("Unexpected loaded parent: %+v", child.User)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package sol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// LockClause is the row locking clause of a SELECT statement, such as
+// FOR UPDATE OF users SKIP LOCKED
+type LockClause struct {
+	strength string
+	of       []Tabular
+	wait     string // SKIP LOCKED or NOWAIT
+	ignore   bool   // Omit the clause if the dialect does not support it
+}
+
+var _ Clause = LockClause{}
+
+// String returns the LockClause in a neutral dialect.
+func (lock LockClause) String() string {
+	compiled, _ := lock.Compile(&defaultDialect{}, Params())
+	return compiled
+}
+
+// Compile outputs the LockClause for the given dialect. It will error
+// if the dialect does not support the lock strength, unless the lock was
+// set to be ignored. An empty string is returned if there is no lock.
+func (lock LockClause) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	if lock.strength == "" {
+		if lock.wait != "" || len(lock.of) > 0 {
+			return "", FieldError{
+				Msg: "sol: Of, SkipLocked, and NoWait require a lock such as ForUpdate",
+			}
+		}
+		return "", nil
+	}
+	if !dialect.Locks(d, lock.strength) {
+		if lock.ignore {
+			return "", nil
+		}
+		return "", FieldError{
+			Clause: lock.strength,
+			Msg: fmt.Sprintf(
+				"sol: the dialect %T does not support %s", d, lock.strength,
+			),
+		}
+	}
+
+	compiled := []string{lock.strength}
+	if len(lock.of) > 0 {
+		names := make([]string, len(lock.of))
+		for i, table := range lock.of {
+			names[i] = tableReference(table)
+		}
+		compiled = append(compiled, OF, strings.Join(names, ", "))
+	}
+	if lock.wait != "" {
+		compiled = append(compiled, lock.wait)
+	}
+	return strings.Join(compiled, WHITESPACE), nil
+}
+
+// validate returns an error if the lock cannot be used with the given
+// statement. Rows cannot be locked once they have been combined.
+func (lock LockClause) validate(stmt SelectStmt) error {
+	var clause string
+	switch {
+	case lock.strength == "":
+		return nil
+	case stmt.isDistinct:
+		clause = DISTINCT
+	case stmt.groupBy.Exists():
+		clause = GROUPBY
+	case stmt.having != nil:
+		clause = HAVING
+	case stmt.isAggregate():
+		clause = "an aggregate function"
+	default:
+		return nil
+	}
+	return FieldError{
+		Clause: lock.strength,
+		Msg: fmt.Sprintf(
+			"sol: %s cannot be used with %s", lock.strength, clause,
+		),
+	}
+}
+
+func (stmt SelectStmt) lockWith(strength string) SelectStmt {
+	stmt.lock.strength = strength
+	return stmt
+}
+
+// ForUpdate locks the selected rows against updates and deletes by
+// other transactions until the current transaction ends.
+func (stmt SelectStmt) ForUpdate() SelectStmt {
+	return stmt.lockWith(FORUPDATE)
+}
+
+// ForNoKeyUpdate is a weaker ForUpdate that allows other transactions
+// to lock the rows with FOR KEY SHARE. It is only supported by postgres.
+func (stmt SelectStmt) ForNoKeyUpdate() SelectStmt {
+	return stmt.lockWith(FORNOKEYUPDATE)
+}
+
+// ForShare locks the selected rows against updates and deletes, but
+// allows other transactions to also lock them with FOR SHARE.
+func (stmt SelectStmt) ForShare() SelectStmt {
+	return stmt.lockWith(FORSHARE)
+}
+
+// Of limits the lock to the rows of the given tables, which is needed
+// when only some tables of a join should be locked.
+func (stmt SelectStmt) Of(tables ...Tabular) SelectStmt {
+	stmt.lock.of = tables
+	return stmt
+}
+
+// SkipLocked skips any rows that cannot be locked immediately. It is
+// useful for selecting jobs from a queue with multiple workers.
+func (stmt SelectStmt) SkipLocked() SelectStmt {
+	stmt.lock.wait = SKIPLOCKED
+	return stmt
+}
+
+// NoWait errors if any rows cannot be locked immediately instead of
+// waiting for the other transactions to end.
+func (stmt SelectStmt) NoWait() SelectStmt {
+	stmt.lock.wait = NOWAIT
+	return stmt
+}
+
+// IgnoreUnsupportedLock omits the lock when the dialect does not support
+// it, such as sqlite3, which locks the whole database during writes.
+// Otherwise, compiling the statement for those dialects will error.
+func (stmt SelectStmt) IgnoreUnsupportedLock() SelectStmt {
+	stmt.lock.ignore = true
+	return stmt
+}
@@ -0,0 +1,48 @@
+package sol
+
+import "testing"
+
+// unlockedDialect is a test dialect without row locks
+type unlockedDialect struct{}
+
+func (d unlockedDialect) Param(i int) string {
+	return "?"
+}
+
+func TestLockClause(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	expect.SQL(
+		users.Select().Where(users.C("id").Equals(1)).ForUpdate(),
+		`SELECT users.id, users.email, users.name, users.password, users.created_at FROM users WHERE users.id = $1 FOR UPDATE`,
+		1,
+	)
+	expect.SQL(
+		Select(users.C("id")).OrderBy(users.C("id")).Limit(1).ForShare().NoWait(),
+		`SELECT users.id FROM users ORDER BY users.id LIMIT 1 FOR SHARE NOWAIT`,
+	)
+
+	// Lock only the rows of some tables
+	u2 := users.As("u2")
+	expect.SQL(
+		Select(contacts.C("id")).InnerJoin(users).InnerJoin(
+			u2, u2.C("id").Equals(contacts.C("user_id")),
+		).ForNoKeyUpdate().Of(contacts, u2).SkipLocked(),
+		`SELECT contacts.id FROM contacts INNER JOIN users ON contacts.user_id = users.id INNER JOIN users AS u2 ON u2.id = contacts.user_id FOR NO KEY UPDATE OF contacts, u2 SKIP LOCKED`,
+	)
+
+	// Locks require a strength and cannot be used with combined rows
+	expect.Error(Select(users.C("id")).SkipLocked())
+	expect.Error(Select(users.C("id")).Distinct().ForUpdate())
+	expect.Error(Select(users.C("name")).GroupBy(users.C("name")).ForShare())
+	expect.Error(Select(Count(users.C("id"))).ForUpdate())
+	expect.Error(Select(Max(users.C("id"))).ForShare())
+
+	// Dialects without locks will error unless the lock is ignored
+	unlocked := NewTester(t, unlockedDialect{})
+	unlocked.Error(Select(users.C("id")).ForUpdate())
+	unlocked.SQL(
+		Select(users.C("id")).ForUpdate().SkipLocked().IgnoreUnsupportedLock(),
+		`SELECT users.id FROM users`,
+	)
+}
@@ -0,0 +1,384 @@
+package sol
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// MetaData is a registry of tables and views that can be created, or
+// dropped, together in the order of their dependencies
+type MetaData struct {
+	tables []*TableElem
+	views  []ViewElem
+}
+
+// Add registers the given tables and views. Adding a table more than
+// once has no effect, but different tables cannot share a name.
+func (md *MetaData) Add(tabulars ...Tabular) error {
+	for _, tabular := range tabulars {
+		if tabular == nil || tabular.Table() == nil {
+			return fmt.Errorf("sol: metadata cannot add a nil table")
+		}
+		table := tabular.Table() // Get the dialect neutral table
+		if table.alias != "" {
+			return fmt.Errorf(
+				"sol: metadata cannot add table %s with alias %s",
+				table.name, table.alias,
+			)
+		}
+		if existing := md.get(table.name); existing == table {
+			continue
+		} else if existing != nil {
+			return fmt.Errorf(
+				"sol: metadata already has a table or view named %s",
+				table.name,
+			)
+		}
+
+		switch t := tabular.(type) {
+		case ViewElem:
+			md.views = append(md.views, t)
+		case *ViewElem:
+			md.views = append(md.views, *t)
+		default:
+			md.tables = append(md.tables, table)
+		}
+	}
+	return nil
+}
+
+// CreateAll creates every table, followed by every view, if they do not
+// already exist. The enumerated types of the tables are created first,
+// and the COMMENT ON statements of the tables follow them. Tables are
+// created before the tables that reference them. When tables reference each other, the foreign keys that complete
+// the cycle are added with ALTER TABLE once all tables are created. Since
+// ALTER TABLE has no IF NOT EXISTS, dialects with an information schema,
+// such as postgres and MySQL, skip the foreign keys that already exist.
+// Views are created in the order they were added, with OR REPLACE if the
+// dialect supports it, and otherwise with IF NOT EXISTS.
+//
+// Every statement is run within a single transaction. Dialects that allow
+// foreign keys to reference tables that do not exist, such as sqlite3,
+// create each foreign key with its table.
+//  schema := sol.NewMetaData(Users, Contacts, UserEmails)
+//  err := schema.CreateAll(conn)
+func (md *MetaData) CreateAll(conn Conn) error {
+	return inTransaction(conn, "CreateAll", func(exec executer, d dialect.Dialect) error {
+		return executeAll(exec, d, md.creates(d))
+	})
+}
+
+// DropAll drops every view, followed by every table, if they exist.
+// Tables are dropped in the reverse of the order they are created. If
+// tables reference each other, they are dropped by a single statement,
+// which both postgres and MySQL allow. Every statement is run within a
+// single transaction.
+func (md *MetaData) DropAll(conn Conn) error {
+	return inTransaction(conn, "DropAll", func(exec executer, d dialect.Dialect) error {
+		return executeAll(exec, d, md.drops(d))
+	})
+}
+
+// Tables returns the registered tables in the order they will be created
+func (md *MetaData) Tables() []*TableElem {
+	order, _ := md.sorted(false)
+	return order
+}
+
+// creates returns the statements that create every table and view
+func (md *MetaData) creates(d dialect.Dialect) []Executable {
+	order, deferred := md.sorted(dialect.ForwardReferences(d))
+
+	var stmts []Executable
+	var tables []Executable
+	var alters []Executable
+	var comments []Executable
+	created := make(map[string]bool)
+	for _, table := range order {
+		create := table.Create().IfNotExists()
+		create.deferred = deferred[table]
+		for _, stmt := range create.Types(d) {
+			name := stmt.(createTypeStmt).enum.Name()
+			if !created[name] {
+				created[name] = true
+				stmts = append(stmts, stmt)
+			}
+		}
+		tables = append(tables, create)
+		for _, fk := range table.ForeignKeys() {
+			if create.defers(fk) {
+				alters = append(alters, addForeignKeyStmt{fk: fk})
+			}
+		}
+		comments = append(comments, create.Comments(d)...)
+	}
+	stmts = append(stmts, tables...)
+	stmts = append(stmts, alters...)
+	stmts = append(stmts, comments...)
+	for _, view := range md.views {
+		if dialect.ReplaceViews(d) {
+			stmts = append(stmts, view.Create().OrReplace())
+		} else {
+			stmts = append(stmts, view.Create().IfNotExists())
+		}
+	}
+	return stmts
+}
+
+// drops returns the statements that drop every view and table
+func (md *MetaData) drops(d dialect.Dialect) []Executable {
+	var stmts []Executable
+	for i := len(md.views) - 1; i >= 0; i-- {
+		stmts = append(stmts, dropAllStmt{
+			kind: "VIEW", names: []string{md.views[i].name},
+		})
+	}
+
+	order, deferred := md.sorted(dialect.ForwardReferences(d))
+	if len(deferred) > 0 {
+		names := make([]string, len(order))
+		for i, table := range order {
+			names[len(order)-1-i] = table.name
+		}
+		return append(stmts, dropAllStmt{kind: "TABLE", names: names})
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		stmts = append(stmts, order[i].Drop().IfExists())
+	}
+	return stmts
+}
+
+// get returns the registered table or view with the given name, or nil
+func (md *MetaData) get(name string) *TableElem {
+	for _, table := range md.tables {
+		if table.name == name {
+			return table
+		}
+	}
+	for _, view := range md.views {
+		if view.name == name {
+			return view.TableElem
+		}
+	}
+	return nil
+}
+
+// has returns true if the given table has been registered
+func (md *MetaData) has(table *TableElem) bool {
+	for _, registered := range md.tables {
+		if registered == table {
+			return true
+		}
+	}
+	return false
+}
+
+// sorted returns the tables in an order that creates each before the
+// tables that reference it. Ties are broken by the order the tables
+// were added. If the remaining tables reference each other, the first
+// table of a cycle is created and, unless forward references are allowed,
+// its references to the others are returned as deferred.
+func (md *MetaData) sorted(forward bool) ([]*TableElem, map[*TableElem][]*TableElem) {
+	// Count the references of each table to other registered tables
+	pending := make(map[*TableElem]int)
+	for _, table := range md.tables {
+		for _, fk := range table.ForeignKeys() {
+			if fk.references != table && md.has(fk.references) {
+				pending[table]++
+			}
+		}
+	}
+
+	created := make(map[*TableElem]bool)
+	deferred := make(map[*TableElem][]*TableElem)
+	order := make([]*TableElem, 0, len(md.tables))
+	for len(order) < len(md.tables) {
+		next, ready := md.next(created, pending)
+		if !ready && !forward {
+			for _, fk := range next.ForeignKeys() {
+				ref := fk.references
+				if ref != next && md.has(ref) && !created[ref] {
+					deferred[next] = append(deferred[next], ref)
+				}
+			}
+		}
+		created[next] = true
+		order = append(order, next)
+
+		// The references to the created table are no longer pending
+		for _, fk := range next.ReferencedBy() {
+			if fk.table != next && md.has(fk.table) {
+				pending[fk.table]--
+			}
+		}
+	}
+	return order, deferred
+}
+
+// next returns the first table that has not been created and is ready:
+// all of the registered tables it references have been created. If no
+// table is ready, the first table of a cycle is returned instead.
+func (md *MetaData) next(created map[*TableElem]bool, pending map[*TableElem]int) (*TableElem, bool) {
+	var first *TableElem
+	for _, table := range md.tables {
+		if created[table] {
+			continue
+		}
+		if pending[table] == 0 {
+			return table, true
+		}
+		if first == nil {
+			first = table
+		}
+	}
+	return md.cycle(first, created), false
+}
+
+// cycle follows the references of the given table to tables that have
+// not been created until one repeats. Since no table is ready, every
+// table has such a reference. It returns the first added table of the
+// cycle that was found.
+func (md *MetaData) cycle(table *TableElem, created map[*TableElem]bool) *TableElem {
+	visited := make(map[*TableElem]int) // The index of each table in path
+	var path []*TableElem
+	for {
+		if i, ok := visited[table]; ok {
+			path = path[i:]
+			break
+		}
+		visited[table] = len(path)
+		path = append(path, table)
+		for _, fk := range table.ForeignKeys() {
+			ref := fk.references
+			if ref != table && md.has(ref) && !created[ref] {
+				table = ref
+				break
+			}
+		}
+	}
+
+	inCycle := make(map[*TableElem]bool)
+	for _, table := range path {
+		inCycle[table] = true
+	}
+	for _, table := range md.tables {
+		if inCycle[table] {
+			return table
+		}
+	}
+	return path[0]
+}
+
+// NewMetaData creates a registry of the given tables and views. It will
+// panic on any errors.
+func NewMetaData(tabulars ...Tabular) *MetaData {
+	md := &MetaData{}
+	if err := md.Add(tabulars...); err != nil {
+		log.Panic(err)
+	}
+	return md
+}
+
+// executeAll executes each of the given statements in order. Foreign
+// keys that already exist are skipped.
+func executeAll(exec executer, d dialect.Dialect, stmts []Executable) error {
+	for _, stmt := range stmts {
+		if add, ok := stmt.(addForeignKeyStmt); ok {
+			exists, err := add.exists(exec, d)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+		}
+		if _, err := execute(exec, d, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addForeignKeyStmt adds a foreign key to an existing table. If the key
+// is not named, it is given the name postgres would: the table and its
+// columns followed by fkey.
+type addForeignKeyStmt struct {
+	fk FKElem
+}
+
+// Compile outputs the ALTER TABLE ... ADD CONSTRAINT statement
+func (stmt addForeignKeyStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	constraint, err := stmt.fk.tableConstraint(d, stmt.name())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"ALTER TABLE %s ADD %s", stmt.fk.table.Name(), constraint,
+	), nil
+}
+
+// exists returns true if the table already has a foreign key with the
+// name of the statement's key. It always returns false if the dialect
+// does not have an information schema.
+func (stmt addForeignKeyStmt) exists(exec executer, d dialect.Dialect) (bool, error) {
+	schema, ok := dialect.CurrentSchema(d)
+	if !ok {
+		return false, nil
+	}
+	var count int64
+	err := queryOne(exec, d, constraintCountStmt{
+		schema: schema,
+		table:  stmt.fk.table.name,
+		name:   stmt.name(),
+	}, &count)
+	return count > 0, err
+}
+
+// name returns the name of the foreign key's constraint
+func (stmt addForeignKeyStmt) name() string {
+	if stmt.fk.constraint != "" {
+		return stmt.fk.constraint
+	}
+	return fmt.Sprintf(
+		"%s_%s_fkey", stmt.fk.table.name, strings.Join(stmt.fk.Names(), "_"),
+	)
+}
+
+// constraintCountStmt counts the foreign keys of the given name on the
+// table of the current schema
+type constraintCountStmt struct {
+	schema, table, name string
+}
+
+// Compile outputs the SELECT from information_schema.table_constraints
+func (stmt constraintCountStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	table, err := NewParam(stmt.table).Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
+	name, err := NewParam(stmt.name).Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"SELECT COUNT(*) FROM information_schema.table_constraints WHERE constraint_type = 'FOREIGN KEY' AND table_schema = %s AND table_name = %s AND constraint_name = %s",
+		stmt.schema, table, name,
+	), nil
+}
+
+// dropAllStmt drops the views or tables of the given names, if they exist,
+// with a single statement
+type dropAllStmt struct {
+	kind  string
+	names []string
+}
+
+// Compile outputs the DROP ... IF EXISTS statement
+func (stmt dropAllStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	return fmt.Sprintf(
+		"DROP %s IF EXISTS %s", stmt.kind, strings.Join(stmt.names, ", "),
+	), nil
+}
@@ -0,0 +1,187 @@
+package sol
+
+import (
+	"testing"
+
+	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+// namedEnumDialect creates enums as types, as postgres does
+type namedEnumDialect struct{ defaultDialect }
+
+func (d namedEnumDialect) Enums() dialect.EnumStyle {
+	return dialect.NamedEnum
+}
+
+func TestMetaData(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	// Tables are created before the tables that reference them, regardless
+	// of the order they were added. Self references are ignored.
+	md := NewMetaData(messages, contacts, users, contacts)
+	if tables := md.Tables(); len(tables) != 3 || tables[0] != users ||
+		tables[1] != messages || tables[2] != contacts {
+		t.Errorf("Unexpected table order: %v", tables)
+	}
+
+	stmts := md.drops(&defaultDialect{})
+	if len(stmts) != 3 {
+		t.Fatalf("Unexpected number of statements: %d != 3", len(stmts))
+	}
+	expect.SQL(stmts[0], `DROP TABLE IF EXISTS contacts`)
+	expect.SQL(stmts[1], `DROP TABLE IF EXISTS messages`)
+	expect.SQL(stmts[2], `DROP TABLE IF EXISTS users`)
+
+	// Names must be unique and tables cannot be aliased
+	if err := md.Add(Table("users")); err == nil {
+		t.Error("MetaData should error when a table name is reused")
+	}
+	if err := md.Add(users.As("u")); err == nil {
+		t.Error("MetaData should error when given an aliased table")
+	}
+	if err := md.Add(nil); err == nil {
+		t.Error("MetaData should error when given a nil table")
+	}
+}
+
+func TestMetaData_cycles(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	departments := Table("departments",
+		Column("id", types.Integer()),
+		Column("manager_id", types.Integer()),
+		PrimaryKey("id"),
+	)
+	employees := Table("employees",
+		Column("id", types.Integer()),
+		ForeignKey("department_id", departments).OnDelete(Cascade),
+		PrimaryKey("id"),
+	)
+	ForeignKeyConstraint(
+		[]string{"manager_id"}, employees, nil,
+	).Named("managed_by").Modify(departments)
+	ForeignKey("mentor_id", employees).Modify(employees)
+
+	view, err := View("managers", employees.Select())
+	if err != nil {
+		t.Fatalf("Unexpected error creating View: %s", err)
+	}
+
+	// The cycle is broken by adding the first table's foreign key later
+	md := NewMetaData(view, departments, employees)
+	stmts := md.creates(&defaultDialect{})
+	if len(stmts) != 4 {
+		t.Fatalf("Unexpected number of statements: %d != 4", len(stmts))
+	}
+	expect.SQL(stmts[0], `CREATE TABLE IF NOT EXISTS departments (
+  id INTEGER,
+  manager_id INTEGER,
+  PRIMARY KEY (id)
+);`)
+	expect.SQL(stmts[1], `CREATE TABLE IF NOT EXISTS employees (
+  id INTEGER,
+  department_id INTEGER REFERENCES departments(id) ON DELETE CASCADE,
+  PRIMARY KEY (id),
+  mentor_id INTEGER REFERENCES employees(id)
+);`)
+	expect.SQL(stmts[2], `ALTER TABLE departments ADD CONSTRAINT managed_by FOREIGN KEY (manager_id) REFERENCES employees(id)`)
+	expect.SQL(stmts[3], `CREATE OR REPLACE VIEW managers AS SELECT employees.id, employees.department_id, employees.mentor_id FROM employees`)
+
+	// Tables that reference each other are dropped together
+	stmts = md.drops(&defaultDialect{})
+	if len(stmts) != 2 {
+		t.Fatalf("Unexpected number of statements: %d != 2", len(stmts))
+	}
+	expect.SQL(stmts[0], `DROP VIEW IF EXISTS managers`)
+	expect.SQL(stmts[1], `DROP TABLE IF EXISTS employees, departments`)
+
+	// Unnamed foreign keys are given the default postgres name
+	md = NewMetaData(employees, departments)
+	stmts = md.creates(&defaultDialect{})
+	if len(stmts) != 3 {
+		t.Fatalf("Unexpected number of statements: %d != 3", len(stmts))
+	}
+	expect.SQL(stmts[0], `CREATE TABLE IF NOT EXISTS employees (
+  id INTEGER,
+  department_id INTEGER,
+  PRIMARY KEY (id),
+  mentor_id INTEGER REFERENCES employees(id)
+);`)
+	expect.SQL(stmts[1], `CREATE TABLE IF NOT EXISTS departments (
+  id INTEGER,
+  manager_id INTEGER,
+  PRIMARY KEY (id),
+  CONSTRAINT managed_by FOREIGN KEY (manager_id) REFERENCES employees(id)
+);`)
+	expect.SQL(stmts[2], `ALTER TABLE employees ADD CONSTRAINT employees_department_id_fkey FOREIGN KEY (department_id) REFERENCES departments(id) ON DELETE CASCADE`)
+}
+
+func TestMetaData_downstreamOfCycle(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	// Tables that only reference a cycle are not part of it
+	authors := Table("authors",
+		Column("id", types.Integer()),
+		Column("book_id", types.Integer()),
+		PrimaryKey("id"),
+	)
+	books := Table("books",
+		Column("id", types.Integer()),
+		ForeignKey("author_id", authors),
+		PrimaryKey("id"),
+	)
+	ForeignKeyConstraint([]string{"book_id"}, books, nil).Modify(authors)
+	reviews := Table("reviews",
+		ForeignKey("book_id", books),
+	)
+
+	md := NewMetaData(reviews, authors, books)
+	if tables := md.Tables(); len(tables) != 3 || tables[0] != authors ||
+		tables[1] != books || tables[2] != reviews {
+		t.Errorf("Unexpected table order: %v", tables)
+	}
+
+	stmts := md.creates(&defaultDialect{})
+	if len(stmts) != 4 {
+		t.Fatalf("Unexpected number of statements: %d != 4", len(stmts))
+	}
+	expect.SQL(stmts[2], `CREATE TABLE IF NOT EXISTS reviews (
+  book_id INTEGER REFERENCES books(id)
+);`)
+	expect.SQL(stmts[3], `ALTER TABLE authors ADD CONSTRAINT authors_book_id_fkey FOREIGN KEY (book_id) REFERENCES books(id)`)
+}
+
+func TestMetaData_typesAndComments(t *testing.T) {
+	d := &namedEnumDialect{}
+	expect := NewTester(t, d)
+
+	// Types are created once, before every table, and comments follow
+	status := types.Enum("status", "todo", "done")
+	projects := Table("projects",
+		Column("id", types.Integer()),
+		Column("status", status),
+		PrimaryKey("id"),
+		Comment("Open projects"),
+	)
+	tasks := Table("tasks",
+		ForeignKey("project_id", projects),
+		Column("status", status),
+	)
+
+	stmts := NewMetaData(tasks, projects).creates(d)
+	if len(stmts) != 4 {
+		t.Fatalf("Unexpected number of statements: %d != 4", len(stmts))
+	}
+	expect.SQL(stmts[0], `DO $$ BEGIN CREATE TYPE status AS ENUM ('todo', 'done'); EXCEPTION WHEN duplicate_object THEN NULL; END $$;`)
+	expect.SQL(stmts[1], `CREATE TABLE IF NOT EXISTS projects (
+  id INTEGER,
+  status status,
+  PRIMARY KEY (id)
+);`)
+	expect.SQL(stmts[2], `CREATE TABLE IF NOT EXISTS tasks (
+  project_id INTEGER REFERENCES projects(id),
+  status status
+);`)
+	expect.SQL(stmts[3], `COMMENT ON TABLE projects IS 'Open projects';`)
+}
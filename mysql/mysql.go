@@ -1,6 +1,9 @@
 package mysql
 
 import (
+	"fmt"
+	"strings"
+
 	_ "github.com/go-sql-driver/mysql" // Register the MySQL driver
 
 	"github.com/aodin/sol/dialect"
@@ -11,12 +14,88 @@ type MySQL struct{}
 
 // The MySQL dialect must implement the dialect.Dialect interface
 var _ dialect.Dialect = &MySQL{}
+var _ dialect.ParamLimiter = &MySQL{}
+var _ dialect.RowValuer = &MySQL{}
+var _ dialect.Locker = &MySQL{}
+var _ dialect.Enumer = &MySQL{}
+var _ dialect.Quoter = &MySQL{}
+var _ dialect.InsertDefaulter = &MySQL{}
+var _ dialect.AutoIncrementer = &MySQL{}
+var _ dialect.Commenter = &MySQL{}
+var _ dialect.ViewReplacer = &MySQL{}
+var _ dialect.Schemer = &MySQL{}
+var _ dialect.Virtualizer = &MySQL{}
 
 // Param returns the MySQL specific parameterization scheme.
 func (d *MySQL) Param(i int) string {
 	return `?`
 }
 
+// MaxParams returns the maximum number of placeholders that MySQL allows
+// in a single prepared statement. Large values may still exceed the
+// server's max_allowed_packet.
+func (d *MySQL) MaxParams() int {
+	return 65535
+}
+
+// AutoIncrement returns an AUTO_INCREMENT primary key
+func (d *MySQL) AutoIncrement() string {
+	return "INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY"
+}
+
+// Comments returns InlineComment since MySQL declares comments with
+// their table or column
+func (d *MySQL) Comments() dialect.CommentStyle {
+	return dialect.InlineComment
+}
+
+// CurrentSchema returns the current DATABASE(), which MySQL treats as
+// its schema
+func (d *MySQL) CurrentSchema() string {
+	return "DATABASE()"
+}
+
+// Enums returns InlineEnum since MySQL declares enums with their column
+func (d *MySQL) Enums() dialect.EnumStyle {
+	return dialect.InlineEnum
+}
+
+// InsertDefaults returns true since MySQL allows DEFAULT in VALUES
+func (d *MySQL) InsertDefaults() bool {
+	return true
+}
+
+// quoted escapes the backslashes and single quotes of MySQL literals
+var quoted = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+// Quote returns a MySQL string literal, which, unless the server is in
+// NO_BACKSLASH_ESCAPES mode, must also escape backslashes
+func (d *MySQL) Quote(s string) string {
+	return fmt.Sprintf("'%s'", quoted.Replace(s))
+}
+
+// Locks returns true for FOR UPDATE and FOR SHARE, which MySQL 8 supports
+// along with OF, SKIP LOCKED, and NOWAIT
+func (d *MySQL) Locks(strength string) bool {
+	return strength == "FOR UPDATE" || strength == "FOR SHARE"
+}
+
+// ReplaceViews returns true since MySQL supports CREATE OR REPLACE VIEW
+func (d *MySQL) ReplaceViews() bool {
+	return true
+}
+
+// RowValues returns true since MySQL supports row value comparisons
+func (d *MySQL) RowValues() bool {
+	return true
+}
+
+// VirtualColumns returns true since MySQL generated columns are VIRTUAL
+// by default
+func (d *MySQL) VirtualColumns() bool {
+	return true
+}
+
 // Dialect is a constructor for the MySQL Dialect
 func Dialect() *MySQL {
 	return &MySQL{}
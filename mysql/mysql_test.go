@@ -8,6 +8,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 
 	"github.com/aodin/sol"
+	"github.com/aodin/sol/types"
 )
 
 const travisCI = "root@tcp(127.0.0.1:3306)/sol_test?parseTime=true"
@@ -39,3 +40,93 @@ func TestMySQL(t *testing.T) {
 	defer conn.Close()
 	sol.IntegrationTest(t, conn, true)
 }
+
+func TestMySQL_Create(t *testing.T) {
+	expect := sol.NewTester(t, &MySQL{})
+
+	// Enums are declared inline
+	tasks := sol.Table("tasks",
+		sol.Column("status", types.Enum("status", "todo", "done").NotNull()),
+	)
+	expect.SQL(
+		tasks.Create(),
+		`CREATE TABLE tasks (
+  status ENUM('todo', 'done') NOT NULL
+);`,
+	)
+
+	// Enum values are escaped as MySQL string literals
+	seps := sol.Table("seps",
+		sol.Column("sep", types.Enum("sep", `\`, "it's")),
+	)
+	expect.SQL(
+		seps.Create(),
+		`CREATE TABLE seps (
+  sep ENUM('\\', 'it''s')
+);`,
+	)
+
+	items := sol.Table("items",
+		sol.Column("id", types.AutoIncrement()),
+	)
+	expect.SQL(
+		items.Create(),
+		`CREATE TABLE items (
+  id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY
+);`,
+	)
+
+	// Options of generated columns follow the expression
+	price := sol.Column("price", types.Integer())
+	prices := sol.Table("prices",
+		sol.Column("code", types.Varchar(8).Collate("utf8mb4_bin")),
+		price,
+		sol.Column("doubled", sol.Generated(
+			types.Integer(),
+			sol.BinaryClause{Pre: price, Post: sol.NewParam(2), Sep: " * "},
+		).Virtual().NotNull()),
+	)
+	expect.SQL(
+		prices.Create(),
+		`CREATE TABLE prices (
+  code VARCHAR(8) COLLATE utf8mb4_bin,
+  price INTEGER,
+  doubled INTEGER GENERATED ALWAYS AS (price * 2) VIRTUAL NOT NULL
+);`,
+	)
+
+	// Default strings also escape backslashes
+	paths := sol.Table("paths",
+		sol.Column("path", types.Varchar(32).Default(`C:\it's`)),
+	)
+	expect.SQL(
+		paths.Create(),
+		`CREATE TABLE paths (
+  path VARCHAR(32) DEFAULT 'C:\\it''s'
+);`,
+	)
+	// Comments and options are declared inline
+	notes := sol.Table("notes",
+		sol.Column("id", types.Integer()).Comment("Note's id"),
+		sol.Comment("User notes"),
+		Engine("InnoDB"),
+		DefaultCharset("utf8mb4"),
+	)
+	expect.SQL(
+		notes.Create(),
+		`CREATE TABLE notes (
+  id INTEGER COMMENT 'Note''s id'
+) ENGINE=InnoDB, DEFAULT CHARSET=utf8mb4, COMMENT='User notes';`,
+	)
+
+	// Foreign keys cannot be deferred
+	expect.SQL(
+		sol.Table("tags", sol.ForeignKey("note_id", notes.C("id"))).Create(),
+		`CREATE TABLE tags (
+  note_id INTEGER REFERENCES notes(id)
+);`,
+	)
+	expect.Error(sol.Table("links",
+		sol.ForeignKey("note_id", notes.C("id")).Deferrable(),
+	).Create())
+}
@@ -0,0 +1,23 @@
+package mysql
+
+import "github.com/aodin/sol"
+
+// Engine sets the storage engine of the table, such as InnoDB
+//  items := sol.Table("items",
+//      sol.Column("id", types.Integer()),
+//      mysql.Engine("InnoDB"),
+//      mysql.DefaultCharset("utf8mb4"),
+//  )
+func Engine(engine string) sol.TableOption {
+	return sol.Option("ENGINE=" + engine)
+}
+
+// DefaultCharset sets the default character set of the table's columns
+func DefaultCharset(charset string) sol.TableOption {
+	return sol.Option("DEFAULT CHARSET=" + charset)
+}
+
+// Collate sets the default collation of the table's columns
+func Collate(collation string) sol.TableOption {
+	return sol.Option("COLLATE=" + collation)
+}
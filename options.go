@@ -6,6 +6,7 @@ import "strings"
 const (
 	OmitEmpty  = "omitempty"  // Skip this field if it has a zero value
 	OmitUpdate = "omitupdate" // Skip this field during updates
+	JSON       = "json"       // Encode and decode this field as JSON
 )
 
 type options []string
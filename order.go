@@ -32,8 +32,10 @@ func (ord OrderedColumn) String() string {
 }
 
 func (ord OrderedColumn) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
-	// TODO error ignored
-	compiled, _ := ord.inner.Compile(d, ps)
+	compiled, err := ord.inner.Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
 	if ord.desc {
 		compiled += " DESC"
 	}
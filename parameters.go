@@ -1,6 +1,10 @@
 package sol
 
-import "github.com/aodin/sol/dialect"
+import (
+	"reflect"
+
+	"github.com/aodin/sol/dialect"
+)
 
 // Parameter is a value that will be passed to the database using a
 // dialect specific parameterization
@@ -9,12 +13,51 @@ type Parameter struct {
 }
 
 // Parameter compilation is dialect dependent. For instance, dialects such
-// as PostGres require the parameter index.
+// as PostGres require the parameter index. Slices will be sent as arrays
+// if the dialect supports them. Constraints compile parameters as literals.
 func (p *Parameter) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
-	ps.Add(p.Value)
+	if ld, ok := d.(literalDialect); ok {
+		return ld.literal(p.Value)
+	}
+	value := p.Value
+	if isArray(reflect.TypeOf(value)) {
+		value, _ = dialect.Array(d, value)
+	}
+	ps.Add(value)
 	return d.Param(ps.Len() - 1), nil
 }
 
+// isArray returns true if the type is a slice that can be sent to and
+// scanned from a dialect's array type. Only slices of booleans, numbers,
+// strings, bytes, or driver.Valuers are arrays. Bytes themselves and
+// slices that are already a driver.Valuer or sql.Scanner are excluded.
+func isArray(typ reflect.Type) bool {
+	if typ == nil || typ.Kind() != reflect.Slice {
+		return false
+	}
+	if typ.Implements(valuerType) || reflect.PtrTo(typ).Implements(scannerType) {
+		return false
+	}
+	return isArrayElem(typ.Elem())
+}
+
+// isArrayElem returns true if the type can be an element of an array
+func isArrayElem(elem reflect.Type) bool {
+	if elem.Implements(valuerType) {
+		return true
+	}
+	switch elem.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return elem.Elem().Kind() == reflect.Uint8 // An array of bytes
+	}
+	return false
+}
+
 // NewParam creates a new *Parameter
 func NewParam(value interface{}) *Parameter {
 	return &Parameter{value}
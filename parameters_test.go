@@ -1,6 +1,11 @@
 package sol
 
-import "testing"
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestParameters_Add(t *testing.T) {
 	ps := Params()
@@ -10,3 +15,64 @@ func TestParameters_Add(t *testing.T) {
 		t.Fatalf("Unexpected length of parameters: %d != 1", len(*ps))
 	}
 }
+
+// arrayDialect is a test dialect that wraps arrays in an arrayParam
+type arrayDialect struct{ defaultDialect }
+
+type arrayParam struct{ a interface{} }
+
+func (d arrayDialect) Array(a interface{}) interface{} {
+	return arrayParam{a}
+}
+
+// valuerList is a slice that is already a driver.Valuer
+type valuerList []string
+
+func (list valuerList) Value() (driver.Value, error) {
+	return strings.Join(list, ","), nil
+}
+
+func TestParameter_array(t *testing.T) {
+	ids := []int64{1, 2}
+	NewTester(t, arrayDialect{}).SQL(
+		users.Select().Where(
+			users.C("id").Equals(ids),
+			users.C("name").Equals([]byte("admin")),
+		),
+		`SELECT users.id, users.email, users.name, users.password, users.created_at FROM users WHERE (users.id = $1 AND users.name = $2)`,
+		arrayParam{ids}, []byte("admin"),
+	)
+
+	// Slices are left as is by dialects without arrays
+	NewTester(t, &defaultDialect{}).SQL(
+		users.Select().Where(users.C("id").Equals(ids)),
+		`SELECT users.id, users.email, users.name, users.password, users.created_at FROM users WHERE users.id = $1`,
+		ids,
+	)
+
+	// Only slices of valid elements that are not already Valuers are arrays
+	arrays := []interface{}{
+		[]string{}, []float64{}, [][]byte{}, []valuerList{},
+	}
+	for _, array := range arrays {
+		if !isArray(reflect.TypeOf(array)) {
+			t.Errorf("%T should be an array", array)
+		}
+	}
+	others := []interface{}{
+		[]byte{}, valuerList{}, []struct{}{}, []interface{}{}, [][]int{}, 1,
+	}
+	for _, other := range others {
+		if isArray(reflect.TypeOf(other)) {
+			t.Errorf("%T should not be an array", other)
+		}
+	}
+
+	var tags []string
+	if dest := arrayDest(arrayDialect{}, &tags); dest != (arrayParam{&tags}) {
+		t.Errorf("Pointers to slices should be scanned as arrays: %#v", dest)
+	}
+	if dest := arrayDest(&defaultDialect{}, &tags); dest != &tags {
+		t.Errorf("Dialects without arrays should scan as is: %#v", dest)
+	}
+}
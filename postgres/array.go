@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+const (
+	ANY      = "ANY"
+	ARRAYAGG = "array_agg"
+)
+
+type array struct {
+	elem      types.Type
+	isNotNull bool
+	isUnique  bool
+}
+
+// array must implement the Type interface
+var _ types.Type = array{}
+
+func (t array) Create(d dialect.Dialect) (string, error) {
+	if t.elem == nil {
+		return "", fmt.Errorf("postgres: arrays require an element type")
+	}
+	compiled, err := t.elem.Create(d)
+	if err != nil {
+		return "", err
+	}
+	compiled += "[]"
+	if t.isNotNull {
+		compiled += " NOT NULL"
+	}
+	if t.isUnique {
+		compiled += " UNIQUE"
+	}
+	return compiled, nil
+}
+
+// IsNotNull returns true if the array is NOT NULL
+func (t array) IsNotNull() bool {
+	return t.isNotNull
+}
+
+func (t array) NotNull() array {
+	t.isNotNull = true
+	return t
+}
+
+func (t array) Unique() array {
+	t.isUnique = true
+	return t
+}
+
+// Array creates an array of the given element type. Modifiers such as
+// NotNull should be applied to the array rather than its elements.
+//  postgres.Array(types.Text()).NotNull()
+func Array(elem types.Type) (t array) {
+	t.elem = elem
+	return
+}
+
+// Any creates a clause that is true if the column equals any element of
+// the given slice, which is sent as a single array parameter. Unlike In,
+// the SQL is the same for any number of elements.
+//  items.C("id").Any([]int64{1, 2, 3})
+func (col ColumnElem) Any(values interface{}) sol.BinaryClause {
+	return sol.BinaryClause{
+		Pre:  col,
+		Post: sol.FuncClause{Name: ANY, Inner: sol.NewParam(values)},
+		Sep:  " = ",
+	}
+}
+
+// Includes creates a clause that is true if any element of the array
+// column equals the given value
+//  items.C("tags").Includes("sale")
+func (col ColumnElem) Includes(value interface{}) sol.BinaryClause {
+	return sol.BinaryClause{
+		Pre:  sol.NewParam(value),
+		Post: sol.FuncClause{Name: ANY, Inner: col},
+		Sep:  " = ",
+	}
+}
+
+// ArrayAgg returns a column wrapped in the array_agg() function
+func ArrayAgg(col sol.Columnar) ColumnElem {
+	return ColumnElem{sol.Function(ARRAYAGG, col)}
+}
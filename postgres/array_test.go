@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/types"
+)
+
+var posts = Table("posts",
+	sol.Column("id", types.Integer().NotNull()),
+	sol.Column("tags", Array(types.Varchar().Limit(32)).NotNull()),
+	sol.Column("scores", Array(types.Integer())),
+	sol.PrimaryKey("id"),
+)
+
+type post struct {
+	ID     int64
+	Tags   []string
+	Scores []int64
+}
+
+func TestArray(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+
+	expect.SQL(
+		posts.Create(),
+		`CREATE TABLE posts (
+  id INTEGER NOT NULL,
+  tags VARCHAR(32)[] NOT NULL,
+  scores INTEGER[],
+  PRIMARY KEY (id)
+);`,
+	)
+
+	_, err := Array(nil).Create(&PostGres{})
+	assert.NotNil(t, err, "Arrays without an element type should error")
+}
+
+func TestColumn_Array(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+	tags := []string{"go", "sql"}
+
+	expect.SQL(
+		posts.Select().Where(posts.C("tags").Contains(tags)),
+		`SELECT posts.id, posts.tags, posts.scores FROM posts WHERE posts.tags @> $1`,
+		pq.Array(tags),
+	)
+
+	expect.SQL(
+		posts.Select().Where(posts.C("tags").Overlap(tags)),
+		`SELECT posts.id, posts.tags, posts.scores FROM posts WHERE posts.tags && $1`,
+		pq.Array(tags),
+	)
+
+	ids := []int64{1, 2, 3}
+	expect.SQL(
+		posts.Select().Where(posts.C("id").Any(ids)),
+		`SELECT posts.id, posts.tags, posts.scores FROM posts WHERE posts.id = ANY($1)`,
+		pq.Array(ids),
+	)
+
+	expect.SQL(
+		posts.Select().Where(posts.C("tags").Includes("go")),
+		`SELECT posts.id, posts.tags, posts.scores FROM posts WHERE $1 = ANY(posts.tags)`,
+		"go",
+	)
+
+	expect.SQL(
+		sol.Select(ArrayAgg(posts.C("id")).As("ids")),
+		`SELECT array_agg(posts.id) AS "ids" FROM posts`,
+	)
+
+	// Inserted slices are sent as arrays
+	expect.SQL(
+		posts.Insert().Values(post{ID: 1, Tags: tags}),
+		`INSERT INTO posts (id, tags, scores) VALUES ($1, $2, $3)`,
+		int64(1), pq.Array(tags), pq.Array([]int64(nil)),
+	)
+}
+
+// TestPostGres_Array tests the sending and scanning of arrays against
+// the postgres database
+func TestPostGres_Array(t *testing.T) {
+	conn := getConn(t) // TODO close
+	tx, err := conn.Begin()
+	require.Nil(t, err, "Creating a new transaction should not error")
+	defer tx.Rollback()
+
+	require.Nil(t, tx.Query(posts.Create().Temporary().IfNotExists()))
+
+	a := post{ID: 1, Tags: []string{"go", "sql"}, Scores: []int64{3, 4}}
+	b := post{ID: 2, Tags: []string{"sql"}, Scores: []int64{}}
+	require.Nil(t, tx.Query(posts.Insert().Values([]post{a, b})))
+
+	var selected []post
+	require.Nil(t, tx.Query(
+		posts.Select().Where(posts.C("tags").Includes("go")),
+		&selected,
+	))
+	require.Equal(t, 1, len(selected))
+	assert.Equal(t, a, selected[0])
+
+	// Each row of a single array column is scanned into a slice
+	var ids [][]int64
+	require.Nil(t, tx.Query(
+		sol.Select(ArrayAgg(posts.C("id"))).Where(posts.C("id").Any([]int64{1, 2})),
+		&ids,
+	))
+	require.Equal(t, 1, len(ids))
+	assert.Equal(t, 2, len(ids[0]))
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql/driver"
 	"fmt"
 
 	"github.com/aodin/sol"
@@ -30,11 +31,29 @@ var _ sol.Columnar = ColumnElem{}
 func (col ColumnElem) operator(op string, param interface{}) sol.BinaryClause {
 	return sol.BinaryClause{
 		Pre:  col,
-		Post: &sol.Parameter{Value: param},
+		Post: &sol.Parameter{Value: col.param(param)},
 		Sep:  fmt.Sprintf(" %s ", op),
 	}
 }
 
+// param encodes values of JSON columns as JSON, unless they are already
+// JSON strings or bytes, or a driver.Valuer
+func (col ColumnElem) param(value interface{}) interface{} {
+	if _, ok := col.Type().(json); !ok {
+		return value
+	}
+	if _, ok := value.(driver.Valuer); ok {
+		return value
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b) // Bytes would be sent as binary
+	}
+	if encoded, err := encodeJSON(value); err == nil {
+		return encoded
+	}
+	return value // Let the driver report the error
+}
+
 func (col ColumnElem) Contains(param interface{}) sol.BinaryClause {
 	return col.operator(Contains, param)
 }
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/aodin/sol"
+)
+
+// preparer is implemented by transactions that can prepare statements,
+// such as the transactions created by sol
+type preparer interface {
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+// CopyStmt is the internal representation of a COPY ... FROM STDIN
+// statement. It is much faster than INSERT for loading many rows.
+// Since the rows are streamed, it cannot be used with Query - use
+// Exec with a transaction instead.
+type CopyStmt struct {
+	insert sol.InsertStmt
+}
+
+// String outputs the COPY statement
+func (stmt CopyStmt) String() string {
+	names, _, err := stmt.insert.Rows()
+	if err != nil {
+		return err.Error()
+	}
+	return pq.CopyIn(stmt.insert.Table().Name(), names...)
+}
+
+// Exec streams the statement's rows to the database within the given
+// transaction and returns the number of rows copied. The rows will not
+// be visible until the transaction is committed.
+func (stmt CopyStmt) Exec(tx sol.TX) (int64, error) {
+	if stmt.insert.Len() == 0 {
+		return 0, fmt.Errorf("postgres: Copy was given no values")
+	}
+	names, rows, err := stmt.insert.Rows()
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if err = copyable(names, row); err != nil {
+			return 0, err
+		}
+	}
+
+	prep, ok := tx.(preparer)
+	if !ok {
+		return 0, fmt.Errorf(
+			"postgres: Copy requires a transaction created by sol, received %T",
+			tx,
+		)
+	}
+
+	copyIn, err := prep.Prepare(pq.CopyIn(stmt.insert.Table().Name(), names...))
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if _, err = copyIn.Exec(row...); err != nil {
+			copyIn.Close()
+			return 0, err
+		}
+	}
+
+	// An Exec without arguments flushes the buffered rows
+	if _, err = copyIn.Exec(); err != nil {
+		copyIn.Close()
+		return 0, err
+	}
+	if err = copyIn.Close(); err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
+
+// copyable returns an error if the row has values, such as DEFAULT, that
+// cannot be streamed
+func copyable(names []string, row []interface{}) error {
+	for i, value := range row {
+		if _, ok := value.(sol.Clause); ok {
+			return fmt.Errorf(
+				"postgres: Copy cannot send %T for column %s - every row must have a value",
+				value, names[i],
+			)
+		}
+	}
+	return nil
+}
+
+// Values sets the rows to copy. It accepts the same types as the Values
+// method of INSERT statements: struct, sol.Values, or a slice of either.
+func (stmt CopyStmt) Values(obj interface{}) CopyStmt {
+	stmt.insert = stmt.insert.Values(obj)
+	return stmt
+}
+
+// Copy creates a COPY ... FROM STDIN statement for the given table. If no
+// column names are given, all columns of the table will be used.
+func Copy(table sol.Tabular, columns ...string) CopyStmt {
+	if table == nil || len(columns) == 0 {
+		return CopyStmt{insert: sol.Insert(table)}
+	}
+	selections := make([]sol.Selectable, len(columns))
+	for i, name := range columns {
+		selections[i] = table.Table().C(name)
+	}
+	return CopyStmt{insert: sol.Insert(selections...)}
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aodin/sol"
+)
+
+func TestCopy_String(t *testing.T) {
+	assert.Equal(t,
+		`COPY "items_b" ("name") FROM STDIN`,
+		itemsB.Copy().Values([]item{{Name: "A"}, {Name: "B"}}).String(),
+	)
+	assert.Equal(t,
+		`COPY "items_b" ("name") FROM STDIN`,
+		Copy(itemsB, "name").Values(sol.Values{"name": "A"}).String(),
+	)
+}
+
+func TestCopy(t *testing.T) {
+	conn := getConn(t) // TODO close
+
+	tx, err := conn.Begin()
+	require.Nil(t, err, "Creating a new transaction should not error")
+	defer tx.Rollback()
+
+	require.Nil(t,
+		tx.Query(itemsB.Create().Temporary().IfNotExists()),
+		`Create table "%s" should not error`, itemsB.Name(),
+	)
+
+	copied, err := itemsB.Copy().Values(
+		[]item{{Name: "A"}, {Name: "B"}, {Name: "C"}},
+	).Exec(tx)
+	require.Nil(t, err, "COPY should not error")
+	assert.Equal(t, int64(3), copied)
+
+	var names []string
+	require.Nil(t, tx.Query(sol.Select(itemsB.C("name")), &names))
+	assert.Equal(t, []string{"A", "B", "C"}, names)
+
+	// Copy requires values
+	_, err = itemsB.Copy().Exec(tx)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+// Exclusion pairs a column with the operator used to compare it in an
+// EXCLUDE constraint
+type Exclusion struct {
+	Column   string
+	Operator string
+}
+
+// With creates an Exclusion of the given column and operator
+func With(column, operator string) Exclusion {
+	return Exclusion{Column: column, Operator: operator}
+}
+
+// ExcludeConstraint is a table EXCLUDE constraint, which guarantees that
+// no two rows match on all of its exclusions.
+type ExcludeConstraint struct {
+	method     string
+	exclusions []Exclusion
+}
+
+var _ types.Type = ExcludeConstraint{}
+var _ sol.Modifier = ExcludeConstraint{}
+
+// Create returns the constraint for CREATE TABLE statements
+func (c ExcludeConstraint) Create(d dialect.Dialect) (string, error) {
+	exclusions := make([]string, len(c.exclusions))
+	for i, exclusion := range c.exclusions {
+		exclusions[i] = fmt.Sprintf(
+			"%s WITH %s", exclusion.Column, exclusion.Operator,
+		)
+	}
+	return fmt.Sprintf(
+		"EXCLUDE USING %s (%s)", c.method, strings.Join(exclusions, ", "),
+	), nil
+}
+
+// Modify implements the sol.Modifier interface. It confirms that every
+// column exists in the table.
+func (c ExcludeConstraint) Modify(tabular sol.Tabular) error {
+	if tabular == nil || tabular.Table() == nil {
+		return fmt.Errorf("postgres: exclude constraints cannot modify a nil table")
+	}
+	if len(c.exclusions) == 0 {
+		return fmt.Errorf("postgres: exclude constraints require at least one column")
+	}
+	table := tabular.Table()
+	for _, exclusion := range c.exclusions {
+		if !table.Has(exclusion.Column) {
+			return fmt.Errorf(
+				"postgres: table '%s' does not have a column '%s'. Is it created after Exclude()?",
+				table.Name(),
+				exclusion.Column,
+			)
+		}
+	}
+	table.AddConstraint(c)
+	return nil
+}
+
+// Using sets the index method of the constraint, which is gist by default
+func (c ExcludeConstraint) Using(method string) ExcludeConstraint {
+	c.method = method
+	return c
+}
+
+// Exclude creates an EXCLUDE constraint from the given exclusions. For
+// instance, to prevent overlapping bookings of the same room:
+//  postgres.Exclude(
+//      postgres.With("room", sol.Equal),
+//      postgres.With("during", postgres.Overlap),
+//  )
+// Using equality in a gist index requires the btree_gist extension.
+func Exclude(exclusions ...Exclusion) ExcludeConstraint {
+	return ExcludeConstraint{method: "gist", exclusions: exclusions}
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aodin/sol"
 	"github.com/aodin/sol/dialect"
@@ -11,11 +12,12 @@ import (
 // statement.
 type InsertStmt struct {
 	sol.InsertStmt
-	onConflict      bool
-	conflictTargets []string
-	values          sol.Values
-	where           sol.Clause
-	returning       sol.ColumnSet
+	onConflict         bool
+	conflictTargets    []string
+	conflictConstraint string
+	values             sol.Values
+	where              sol.Clause
+	returning          sol.ColumnSet
 }
 
 // String outputs the parameter-less INSERT ... RETURNING statement in the
@@ -36,7 +38,11 @@ func (stmt InsertStmt) Compile(d dialect.Dialect, ps *sol.Parameters) (string, e
 
 	if stmt.onConflict {
 		compiled += " ON CONFLICT"
-		// TODO conflict targets
+		if stmt.conflictConstraint != "" {
+			compiled += fmt.Sprintf(" ON CONSTRAINT %s", stmt.conflictConstraint)
+		} else if len(stmt.conflictTargets) > 0 {
+			compiled += fmt.Sprintf(" (%s)", strings.Join(stmt.conflictTargets, ", "))
+		}
 		if len(stmt.values) > 0 {
 			compiledValues, err := stmt.values.Compile(d, ps)
 			if err != nil {
@@ -71,6 +77,17 @@ func (stmt InsertStmt) Compile(d dialect.Dialect, ps *sol.Parameters) (string, e
 // DO NOTHING.
 func (stmt InsertStmt) OnConflict(targets ...string) InsertStmt {
 	stmt.conflictTargets = targets
+	stmt.conflictConstraint = ""
+	stmt.onConflict = true
+	return stmt
+}
+
+// OnConstraint adds UPSERT behavior to the INSERT that is targeted by
+// the name of a constraint, such as a named unique constraint:
+//  items.Insert().Values(item).OnConstraint("items_sku_key").DoNothing()
+func (stmt InsertStmt) OnConstraint(name string) InsertStmt {
+	stmt.conflictTargets = nil
+	stmt.conflictConstraint = name
 	stmt.onConflict = true
 	return stmt
 }
@@ -111,6 +128,7 @@ func (stmt InsertStmt) RemoveOnConflict() InsertStmt {
 	stmt.onConflict = false
 	stmt.values = sol.Values{}
 	stmt.conflictTargets = nil
+	stmt.conflictConstraint = ""
 	stmt.where = nil
 	return stmt
 }
@@ -133,19 +151,27 @@ func (stmt InsertStmt) Returning(selections ...sol.Selectable) InsertStmt {
 	// If selections have been specified, use those instead
 	for _, selection := range selections {
 		if selection == nil {
-			stmt.AddMeta(
-				"postgres: received a nil selectable in Returning() - do the columns or tables you selected exist?",
-			)
+			stmt.AddError(sol.FieldError{
+				Kind:   sol.NilSelectable,
+				Clause: "RETURNING",
+				Msg:    "postgres: received a nil selectable in Returning() - do the columns or tables you selected exist?",
+			})
 			return stmt
 		}
 
 		// All selected columns must belong to the INSERT table
 		for _, column := range selection.Columns() {
 			if column.Table() != stmt.Table() {
-				stmt.AddMeta(
-					"postgres: the column '%s' in Returning() does not belong to the inserted table '%s'",
-					column.Name(), stmt.Table().Name(),
-				)
+				stmt.AddError(sol.FieldError{
+					Kind:   sol.WrongTable,
+					Column: column.Name(),
+					Table:  stmt.Table().Name(),
+					Clause: "RETURNING",
+					Msg: fmt.Sprintf(
+						"postgres: the column '%s' in Returning() does not belong to the inserted table '%s'",
+						column.Name(), stmt.Table().Name(),
+					),
+				})
 				break
 			}
 			stmt.returning, _ = stmt.returning.Add(column)
@@ -154,6 +180,18 @@ func (stmt InsertStmt) Returning(selections ...sol.Selectable) InsertStmt {
 	return stmt
 }
 
+// Slice returns a copy of the statement with only the rows of values
+// from i to j
+func (stmt InsertStmt) Slice(i, j int) InsertStmt {
+	stmt.InsertStmt = stmt.InsertStmt.Slice(i, j)
+	return stmt
+}
+
+// Batch implements the sol.Batcher interface. It is an alias for Slice.
+func (stmt InsertStmt) Batch(i, j int) sol.Executable {
+	return stmt.Slice(i, j)
+}
+
 // Values proxies to the inner InsertStmt's Values method
 func (stmt InsertStmt) Values(args interface{}) InsertStmt {
 	stmt.InsertStmt = stmt.InsertStmt.Values(args)
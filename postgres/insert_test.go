@@ -41,6 +41,20 @@ func TestInsert(t *testing.T) {
 		nil, nil,
 	)
 
+	expect.SQL(
+		meetings.Insert().OnConflict("uuid").DoNothing(),
+		`INSERT INTO meetings (uuid, time) VALUES ($1, $2) ON CONFLICT (uuid) DO NOTHING`,
+		nil, nil,
+	)
+
+	expect.SQL(
+		meetings.Insert().OnConstraint("meetings_pkey").DoUpdate(
+			sol.Values{"time": now},
+		),
+		`INSERT INTO meetings (uuid, time) VALUES ($1, $2) ON CONFLICT ON CONSTRAINT meetings_pkey DO UPDATE SET time = $3`,
+		nil, nil, now,
+	)
+
 	// Selecting a column or table that is not part of the insert table
 	// should produce an error
 	expect.Error(meetings.Insert().Returning(things))
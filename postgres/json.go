@@ -1,15 +1,39 @@
 package postgres
 
 import (
+	encoding "encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/aodin/sol"
 	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
+)
+
+const (
+	GetField    = "->"
+	GetText     = "->>"
+	GetPath     = "#>"
+	GetPathText = "#>>"
+	HasKey      = "?"
+	HasAnyKey   = "?|"
+	HasAllKeys  = "?&"
+	JSONBSet    = "jsonb_set"
 )
 
 type json struct {
-	name      string
-	isNotNull bool
-	isUnique  bool
+	name         string
+	isNotNull    bool
+	isUnique     bool
+	defaultValue interface{}
 }
 
+// json must implement the Type interface
+var _ types.Type = json{}
+var _ types.Defaulter = json{}
+
 func (t json) Create(d dialect.Dialect) (string, error) {
 	compiled := t.name
 	if t.isNotNull {
@@ -18,9 +42,34 @@ func (t json) Create(d dialect.Dialect) (string, error) {
 	if t.isUnique {
 		compiled += " UNIQUE"
 	}
+	if t.defaultValue != nil {
+		value, err := encodeJSON(t.defaultValue)
+		if err != nil {
+			return "", err
+		}
+		compiled += fmt.Sprintf(" DEFAULT %s", dialect.Quote(d, value))
+	}
 	return compiled, nil
 }
 
+// Default sets the default value of the column. Strings and bytes are
+// assumed to be JSON, other values will be encoded.
+//  postgres.JSONB().Default(map[string]interface{}{})
+func (t json) Default(value interface{}) json {
+	t.defaultValue = value
+	return t
+}
+
+// HasDefault returns true if the column has a DEFAULT
+func (t json) HasDefault() bool {
+	return t.defaultValue != nil
+}
+
+// IsNotNull returns true if the json is NOT NULL
+func (t json) IsNotNull() bool {
+	return t.isNotNull
+}
+
 func (t json) NotNull() json {
 	t.isNotNull = true
 	return t
@@ -35,3 +84,158 @@ func JSON() (t json) {
 	t.name = "json"
 	return
 }
+
+func JSONB() (t json) {
+	t.name = "jsonb"
+	return
+}
+
+// encodeJSON returns the value as JSON text. Strings and bytes are
+// assumed to already be JSON.
+func encodeJSON(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case encoding.RawMessage:
+		return string(v), nil
+	}
+	b, err := encoding.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf(
+			"postgres: unable to encode %T as JSON: %s", value, err,
+		)
+	}
+	return string(b), nil
+}
+
+// pathLiteral returns the given keys as a text array literal
+func pathLiteral(keys ...interface{}) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = fmt.Sprintf(`"%s"`, escaper.Replace(fmt.Sprint(key)))
+	}
+	return dialect.Quote(
+		&PostGres{}, fmt.Sprintf("{%s}", strings.Join(quoted, ",")),
+	)
+}
+
+// jsonOperator extracts a value from a JSON column. Since operators
+// cannot be parameterized, keys are included as literals.
+type jsonOperator struct {
+	op  string
+	key string
+}
+
+// Wrap implements the sol.Operator interface
+func (op jsonOperator) Wrap(str string) string {
+	return fmt.Sprintf("%s%s%s", str, op.op, op.key)
+}
+
+// jsonKey returns an integer as an array index and anything else as a
+// quoted object key
+func jsonKey(key interface{}) string {
+	switch key.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(key)
+	}
+	return dialect.Quote(&PostGres{}, fmt.Sprint(key))
+}
+
+// jsonbSet replaces the value at a path of a JSONB column. It will
+// fail to compile if the value could not be encoded.
+type jsonbSet struct {
+	path  string
+	value string
+	err   error
+}
+
+var _ sol.FailingOperator = jsonbSet{}
+
+// Err implements the sol.FailingOperator interface
+func (op jsonbSet) Err() error {
+	return op.err
+}
+
+// Wrap implements the sol.Operator interface
+func (op jsonbSet) Wrap(str string) string {
+	return fmt.Sprintf("%s(%s, %s, %s)", JSONBSet, str, op.path, op.value)
+}
+
+// Get returns the JSON value of the given object key, or array element
+// if an integer is given. Calls can be chained to descend further.
+//  contacts.C("info").Get("address").GetText("city")
+func (col ColumnElem) Get(key interface{}) ColumnElem {
+	return ColumnElem{
+		col.WrapOperator(jsonOperator{op: GetField, key: jsonKey(key)}),
+	}
+}
+
+// GetText returns the given object key or array element as text
+func (col ColumnElem) GetText(key interface{}) ColumnElem {
+	return ColumnElem{
+		col.WrapOperator(jsonOperator{op: GetText, key: jsonKey(key)}),
+	}
+}
+
+// Path returns the JSON value at the given path of keys
+//  contacts.C("info").Path("address", "city")
+func (col ColumnElem) Path(keys ...interface{}) ColumnElem {
+	return ColumnElem{
+		col.WrapOperator(jsonOperator{op: GetPath, key: pathLiteral(keys...)}),
+	}
+}
+
+// PathText returns the value at the given path of keys as text
+func (col ColumnElem) PathText(keys ...interface{}) ColumnElem {
+	return ColumnElem{
+		col.WrapOperator(jsonOperator{op: GetPathText, key: pathLiteral(keys...)}),
+	}
+}
+
+// HasKey creates a clause that is true if the JSONB column has the
+// given top-level key
+func (col ColumnElem) HasKey(key string) sol.BinaryClause {
+	return col.operator(HasKey, key)
+}
+
+// HasAnyKey creates a clause that is true if the JSONB column has any
+// of the given top-level keys
+func (col ColumnElem) HasAnyKey(keys ...string) sol.BinaryClause {
+	return col.operator(HasAnyKey, pq.Array(keys))
+}
+
+// HasAllKeys creates a clause that is true if the JSONB column has all
+// of the given top-level keys
+func (col ColumnElem) HasAllKeys(keys ...string) sol.BinaryClause {
+	return col.operator(HasAllKeys, pq.Array(keys))
+}
+
+// Set returns the JSONB column with the value at the given path
+// replaced. The value is encoded as JSON unless it is a string or bytes;
+// if encoding fails, the column will return an error when compiled.
+// Since operators cannot be parameterized, the value is included in the
+// statement as a quoted literal. It can be used to update part of a
+// column:
+//  contacts.Update().Values(sol.Values{
+//      "info": contacts.C("info").Set([]string{"address"}, address),
+//  })
+func (col ColumnElem) Set(path []string, value interface{}) ColumnElem {
+	keys := make([]interface{}, len(path))
+	for i, key := range path {
+		keys[i] = key
+	}
+	op := jsonbSet{path: pathLiteral(keys...)}
+	if encoded, err := encodeJSON(value); err != nil {
+		op.err = sol.FieldError{
+			Kind:   sol.UnsupportedValue,
+			Column: col.Name(),
+			Msg:    err.Error(),
+		}
+	} else {
+		op.value = dialect.Quote(&PostGres{}, encoded)
+	}
+	return ColumnElem{col.WrapOperator(op)}
+}
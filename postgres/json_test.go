@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/types"
+)
+
+var contacts = Table("contacts",
+	sol.Column("id", types.Integer().NotNull()),
+	sol.Column("info", JSONB().NotNull().Default(map[string]interface{}{})),
+	sol.PrimaryKey("id"),
+)
+
+type address struct {
+	City  string `json:"city"`
+	State string `json:"state"`
+}
+
+type info struct {
+	Name    string  `json:"name"`
+	Address address `json:"address"`
+}
+
+type contact struct {
+	ID   int64
+	Info info `db:",json"`
+}
+
+func TestJSON(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+
+	expect.SQL(
+		contacts.Create(),
+		`CREATE TABLE contacts (
+  id INTEGER NOT NULL,
+  info jsonb NOT NULL DEFAULT '{}',
+  PRIMARY KEY (id)
+);`,
+	)
+
+	// Literals are escaped
+	quotes := JSON().Default(map[string]string{"name": "O'Brien"})
+	compiled, err := quotes.Create(&PostGres{})
+	require.Nil(t, err)
+	assert.Equal(t, `json DEFAULT '{"name":"O''Brien"}'`, compiled)
+
+	_, err = JSON().Default(make(chan int)).Create(&PostGres{})
+	assert.NotNil(t, err, "Unencodable defaults should error")
+}
+
+func TestColumn_JSON(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+	info := contacts.C("info")
+
+	expect.SQL(
+		contacts.Select().Where(info.Contains(map[string]string{"name": "A"})),
+		`SELECT contacts.id, contacts.info FROM contacts WHERE contacts.info @> $1`,
+		`{"name":"A"}`,
+	)
+
+	expect.SQL(
+		sol.Select(
+			info.Get("address").GetText("city").As("city"),
+			info.Path("address", "state"),
+			info.PathText("tags", 0),
+			info.Get("tags").Get(0),
+		),
+		`SELECT contacts.info->'address'->>'city' AS "city", contacts.info#>'{"address","state"}', contacts.info#>>'{"tags","0"}', contacts.info->'tags'->0 FROM contacts`,
+	)
+
+	expect.SQL(
+		contacts.Select().Where(
+			info.GetText("name").Equals("O'Brien"),
+			info.HasKey("address"),
+		).OrderBy(info.GetText("name").Desc()),
+		`SELECT contacts.id, contacts.info FROM contacts WHERE (contacts.info->>'name' = $1 AND contacts.info ? $2) ORDER BY contacts.info->>'name' DESC`,
+		"O'Brien", "address",
+	)
+
+	// Keys are escaped
+	expect.SQL(
+		sol.Select(info.Get("it's"), info.Path(`"quoted"`)),
+		`SELECT contacts.info->'it''s', contacts.info#>'{"\"quoted\""}' FROM contacts`,
+	)
+
+	expect.SQL(
+		contacts.Select().Where(info.HasAnyKey("a", "b")),
+		`SELECT contacts.id, contacts.info FROM contacts WHERE contacts.info ?| $1`,
+		pq.Array([]string{"a", "b"}),
+	)
+
+	expect.SQL(
+		contacts.Update().Values(sol.Values{
+			"info": info.Set([]string{"address"}, address{City: "Denver"}),
+		}).Where(contacts.C("id").Equals(1)),
+		`UPDATE contacts SET info = jsonb_set(contacts.info, '{"address"}', '{"city":"Denver","state":""}') WHERE contacts.id = $1`,
+		1,
+	)
+
+	// Values that cannot be encoded error when compiled
+	expect.Error(contacts.Update().Values(sol.Values{
+		"info": info.Set([]string{"a"}, make(chan int)),
+	}))
+}
+
+// TestPostGres_JSON tests the encoding and decoding of JSON columns
+// against the postgres database
+func TestPostGres_JSON(t *testing.T) {
+	conn := getConn(t) // TODO close
+	tx, err := conn.Begin()
+	require.Nil(t, err, "Creating a new transaction should not error")
+	defer tx.Rollback()
+
+	require.Nil(t, tx.Query(contacts.Create().Temporary().IfNotExists()))
+
+	a := contact{ID: 1, Info: info{Name: "A", Address: address{City: "Denver"}}}
+	require.Nil(t, tx.Query(contacts.Insert().Values(a)))
+
+	var selected []contact
+	require.Nil(t, tx.Query(
+		contacts.Select().Where(
+			contacts.C("info").PathText("address", "city").Equals("Denver"),
+		),
+		&selected,
+	))
+	require.Equal(t, 1, len(selected))
+	assert.Equal(t, a, selected[0])
+
+	var city string
+	require.Nil(t, tx.Query(
+		sol.Select(contacts.C("info").Get("address").GetText("city")),
+		&city,
+	))
+	assert.Equal(t, "Denver", city)
+}
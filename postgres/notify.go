@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	encoding "encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/dialect"
+)
+
+// NotifyStmt sends a notification to every session listening on a
+// channel. Since NOTIFY does not accept parameters, it is compiled
+// as SELECT pg_notify($1, $2).
+type NotifyStmt struct {
+	channel string
+	payload interface{}
+}
+
+// String outputs the SELECT pg_notify($1, $2) statement of the
+// notification in the postgres dialect.
+func (stmt NotifyStmt) String() string {
+	compiled, err := stmt.Compile(&PostGres{}, sol.Params())
+	if err != nil {
+		return err.Error()
+	}
+	return compiled
+}
+
+// Compile outputs the SELECT pg_notify statement using the given dialect
+// and parameters. Payloads that are not strings or bytes will be encoded
+// as JSON.
+func (stmt NotifyStmt) Compile(d dialect.Dialect, ps *sol.Parameters) (string, error) {
+	if stmt.channel == "" {
+		return "", fmt.Errorf("postgres: Notify requires a channel")
+	}
+	var payload string
+	switch value := stmt.payload.(type) {
+	case nil:
+	case string:
+		payload = value
+	case []byte:
+		payload = string(value)
+	default:
+		b, err := encoding.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf(
+				"postgres: unable to encode the Notify payload as JSON: %s", err,
+			)
+		}
+		payload = string(b)
+	}
+	channel, err := sol.NewParam(stmt.channel).Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
+	param, err := sol.NewParam(payload).Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`SELECT pg_notify(%s, %s)`, channel, param), nil
+}
+
+// Notify creates a NOTIFY statement for the given channel and payload.
+// Strings and bytes are sent as is, other payloads as JSON. Notifications
+// sent within a transaction are delivered once it commits.
+//  conn.Query(postgres.Notify("users", user))
+func Notify(channel string, payload interface{}) NotifyStmt {
+	return NotifyStmt{channel: channel, payload: payload}
+}
+
+// Notification is a notification received by a Listener
+type Notification struct {
+	Channel string
+	Payload string
+	PID     int // Process ID of the notifying session
+
+	// Reconnected is true for the notification sent after the listener
+	// reconnects, since any notifications in between were lost
+	Reconnected bool
+}
+
+// Decode unmarshals the notification's JSON payload into the given
+// destination
+func (n Notification) Decode(dest interface{}) error {
+	if err := encoding.Unmarshal([]byte(n.Payload), dest); err != nil {
+		return fmt.Errorf(
+			"postgres: unable to decode notification from %s: %s",
+			n.Channel, err,
+		)
+	}
+	return nil
+}
+
+// Listener receives notifications on a dedicated connection, which will
+// be reconnected if lost. Notifications must be received from the
+// Notifications channel, which is closed when the Listener is closed.
+type Listener struct {
+	listener      *pq.Listener
+	notifications chan Notification
+	done          chan struct{}
+	once          sync.Once
+}
+
+// Notifications returns the channel that receives notifications
+func (l *Listener) Notifications() <-chan Notification {
+	return l.notifications
+}
+
+// Listen starts listening on the given channels
+func (l *Listener) Listen(channels ...string) error {
+	for _, channel := range channels {
+		if err := l.listener.Listen(channel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unlisten stops listening on the given channels
+func (l *Listener) Unlisten(channels ...string) error {
+	for _, channel := range channels {
+		if err := l.listener.Unlisten(channel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the listener and closes its connection
+func (l *Listener) Close() error {
+	var err error
+	l.once.Do(func() {
+		close(l.done)
+		err = l.listener.Close()
+	})
+	return err
+}
+
+// receive forwards notifications until the listener is closed. The
+// connection is pinged when idle so that lost connections are noticed.
+func (l *Listener) receive(ping time.Duration) {
+	defer close(l.notifications)
+	for {
+		var notification Notification
+		select {
+		case <-l.done:
+			return
+		case <-time.After(ping):
+			go l.listener.Ping()
+			continue
+		case n, ok := <-l.listener.NotificationChannel():
+			if !ok {
+				return
+			}
+			if n == nil {
+				notification.Reconnected = true
+			} else {
+				notification.Channel = n.Channel
+				notification.Payload = n.Extra
+				notification.PID = n.BePid
+			}
+		}
+
+		select {
+		case <-l.done:
+			return
+		case l.notifications <- notification:
+		}
+	}
+}
+
+// Listen opens a Listener with the given credentials and starts listening
+// on the given channels. An error is returned if the first connection
+// fails. Once connected, reconnection is attempted after 10 seconds and
+// then at most every minute.
+//  listener, err := postgres.Listen(credentials, "users")
+//  for notification := range listener.Notifications() {
+//      var user User
+//      notification.Decode(&user)
+//  }
+func Listen(credentials string, channels ...string) (*Listener, error) {
+	// Only the outcome of the first connection attempt is received, since
+	// the listener would otherwise block while it retries
+	connected := make(chan error, 1)
+	events := func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventConnected:
+		case pq.ListenerEventConnectionAttemptFailed:
+		default:
+			return
+		}
+		select {
+		case connected <- err:
+		default:
+		}
+	}
+
+	l := &Listener{
+		listener: pq.NewListener(
+			credentials, 10*time.Second, time.Minute, events,
+		),
+		notifications: make(chan Notification),
+		done:          make(chan struct{}),
+	}
+	if err := <-connected; err != nil {
+		l.listener.Close()
+		return nil, err
+	}
+	if err := l.Listen(channels...); err != nil {
+		l.listener.Close()
+		return nil, err
+	}
+	go l.receive(90 * time.Second)
+	return l, nil
+}
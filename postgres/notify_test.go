@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aodin/sol"
+)
+
+func TestNotify(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+
+	expect.SQL(
+		Notify("items", "updated"),
+		`SELECT pg_notify($1, $2)`,
+		"items", "updated",
+	)
+	expect.SQL(
+		Notify("items", item{ID: 1, Name: "A"}),
+		`SELECT pg_notify($1, $2)`,
+		"items", `{"ID":1,"Name":"A"}`,
+	)
+	expect.SQL(Notify("items", nil), `SELECT pg_notify($1, $2)`, "items", "")
+
+	expect.Error(Notify("", "updated"))
+	expect.Error(Notify("items", make(chan int)))
+}
+
+func TestListen_unreachable(t *testing.T) {
+	// Listen returns once the first connection attempt fails
+	_, err := Listen("host=localhost port=1 sslmode=disable connect_timeout=1")
+	assert.NotNil(t, err, "Listen should error when the database is unreachable")
+}
+
+func TestNotification_Decode(t *testing.T) {
+	var decoded item
+	notification := Notification{Channel: "items", Payload: `{"ID":1,"Name":"A"}`}
+	require.Nil(t, notification.Decode(&decoded))
+	assert.Equal(t, item{ID: 1, Name: "A"}, decoded)
+
+	notification.Payload = "updated"
+	assert.NotNil(t, notification.Decode(&decoded))
+}
+
+func TestListen(t *testing.T) {
+	conn := getConn(t) // TODO close
+	require.Nil(t, conn.Ping(), "The postgres test database must be available")
+
+	listener, err := Listen(getCredentials(), "items")
+	require.Nil(t, err, "Listen should not error")
+	defer listener.Close()
+
+	require.Nil(t, conn.Query(Notify("items", item{ID: 1, Name: "A"})))
+
+	select {
+	case notification := <-listener.Notifications():
+		assert.Equal(t, "items", notification.Channel)
+		var decoded item
+		require.Nil(t, notification.Decode(&decoded))
+		assert.Equal(t, item{ID: 1, Name: "A"}, decoded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Listener should receive the notification")
+	}
+
+	require.Nil(t, listener.Close())
+	_, open := <-listener.Notifications()
+	assert.False(t, open, "Notifications should be closed with the Listener")
+}
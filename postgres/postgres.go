@@ -2,8 +2,9 @@ package postgres
 
 import (
 	"fmt"
+	"strings"
 
-	_ "github.com/lib/pq" // Register the PostGres driver
+	"github.com/lib/pq" // Register the PostGres driver
 
 	"github.com/aodin/sol/dialect"
 )
@@ -13,12 +14,107 @@ type PostGres struct{}
 
 // The PostGres dialect must implement the dialect.Dialect interface
 var _ dialect.Dialect = &PostGres{}
+var _ dialect.ParamLimiter = &PostGres{}
+var _ dialect.RowValuer = &PostGres{}
+var _ dialect.Locker = &PostGres{}
+var _ dialect.DistinctOner = &PostGres{}
+var _ dialect.Arrayer = &PostGres{}
+var _ dialect.Enumer = &PostGres{}
+var _ dialect.Sequencer = &PostGres{}
+var _ dialect.InsertDefaulter = &PostGres{}
+var _ dialect.AutoIncrementer = &PostGres{}
+var _ dialect.Returner = &PostGres{}
+var _ dialect.Collater = &PostGres{}
+var _ dialect.Commenter = &PostGres{}
+var _ dialect.Deferrer = &PostGres{}
+var _ dialect.ViewReplacer = &PostGres{}
+var _ dialect.Schemer = &PostGres{}
 
 // Param returns the postgres specific parameterization scheme.
 func (d *PostGres) Param(i int) string {
 	return fmt.Sprintf(`$%d`, i+1)
 }
 
+// MaxParams returns the maximum number of parameters that postgres allows
+// in a single statement.
+func (d *PostGres) MaxParams() int {
+	return 65535
+}
+
+// Array wraps slices as postgres arrays
+func (d *PostGres) Array(a interface{}) interface{} {
+	return pq.Array(a)
+}
+
+// AutoIncrement returns an identity column, which, unlike serial, allows
+// ids to be given explicitly
+func (d *PostGres) AutoIncrement() string {
+	return "INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+}
+
+// Collation quotes the name of the collation, such as "C" or "en_US",
+// since unquoted names would be lowercased
+func (d *PostGres) Collation(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.Replace(name, `"`, `""`, -1))
+}
+
+// Comments returns CommentOn since postgres comments are created with
+// COMMENT ON statements
+func (d *PostGres) Comments() dialect.CommentStyle {
+	return dialect.CommentOn
+}
+
+// CurrentSchema returns the current_schema() of the search path
+func (d *PostGres) CurrentSchema() string {
+	return "current_schema()"
+}
+
+// DistinctOn returns true since DISTINCT ON is a postgres extension
+func (d *PostGres) DistinctOn() bool {
+	return true
+}
+
+// Enums returns NamedEnum since postgres enums are types that must be
+// created before they are used
+func (d *PostGres) Enums() dialect.EnumStyle {
+	return dialect.NamedEnum
+}
+
+// InsertDefaults returns true since postgres allows DEFAULT in VALUES
+func (d *PostGres) InsertDefaults() bool {
+	return true
+}
+
+// NextVal returns the nextval() of the given sequence
+func (d *PostGres) NextVal(sequence string) string {
+	return fmt.Sprintf("nextval(%s)", dialect.Quote(d, sequence))
+}
+
+// Locks returns true since postgres supports every lock strength
+func (d *PostGres) Locks(strength string) bool {
+	return true
+}
+
+// Deferrable returns true since postgres foreign keys can be DEFERRABLE
+func (d *PostGres) Deferrable() bool {
+	return true
+}
+
+// ReplaceViews returns true since postgres supports CREATE OR REPLACE VIEW
+func (d *PostGres) ReplaceViews() bool {
+	return true
+}
+
+// Returning returns true since postgres supports INSERT ... RETURNING
+func (d *PostGres) Returning() bool {
+	return true
+}
+
+// RowValues returns true since postgres supports row value comparisons
+func (d *PostGres) RowValues() bool {
+	return true
+}
+
 // Dialect is a constructor for the PostGres Dialect
 func Dialect() *PostGres {
 	return &PostGres{}
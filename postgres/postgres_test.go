@@ -19,17 +19,20 @@ const travisCI = "host=localhost port=5432 dbname=sol_test user=postgres sslmode
 var testconn *sol.DB
 var once sync.Once
 
-// getConn returns a postgres connection pool
-func getConn(t *testing.T) *sol.DB {
+// getCredentials returns the credentials of the test database
+func getCredentials() string {
 	// Check if an ENV VAR has been set, otherwise, use travis
-	credentials := os.Getenv("SOL_TEST_POSTGRES")
-	if credentials == "" {
-		credentials = travisCI
+	if credentials := os.Getenv("SOL_TEST_POSTGRES"); credentials != "" {
+		return credentials
 	}
+	return travisCI
+}
 
+// getConn returns a postgres connection pool
+func getConn(t *testing.T) *sol.DB {
 	once.Do(func() {
 		var err error
-		if testconn, err = sol.Open("postgres", credentials); err != nil {
+		if testconn, err = sol.Open("postgres", getCredentials()); err != nil {
 			t.Fatalf("Failed to open connection: %s", err)
 		}
 		testconn.SetMaxOpenConns(20)
@@ -129,6 +132,77 @@ func TestPostGres_Create(t *testing.T) {
   name VARCHAR
 );`,
 	)
+
+	// Enum types are separate statements, created only once per table
+	status := types.Enum("status", "todo", "done")
+	tasks := Table("tasks",
+		sol.Column("status", status.NotNull()),
+		sol.Column("previous", status),
+	)
+	expect.SQL(
+		tasks.Create(),
+		`CREATE TABLE tasks (
+  status status NOT NULL,
+  previous status
+);`,
+	)
+	enums := tasks.Create().Types(&PostGres{})
+	require.Equal(t, 1, len(enums))
+	expect.SQL(enums[0], `CREATE TYPE status AS ENUM ('todo', 'done');`)
+
+	enums = tasks.Create().IfNotExists().Types(&PostGres{})
+	require.Equal(t, 1, len(enums))
+	expect.SQL(
+		enums[0],
+		`DO $$ BEGIN CREATE TYPE status AS ENUM ('todo', 'done'); EXCEPTION WHEN duplicate_object THEN NULL; END $$;`,
+	)
+
+	// Defaults can be the next value of a sequence
+	orders := Table("orders",
+		sol.Column("number", types.BigInt().Default(types.Sequence("order_numbers"))),
+	)
+	expect.SQL(
+		orders.Create(),
+		`CREATE TABLE orders (
+  number BIGINT DEFAULT nextval('order_numbers')
+);`,
+	)
+
+	// Collations are quoted and generated columns are stored
+	name := sol.Column("name", types.Varchar(32).Collate("C"))
+	people := Table("people",
+		name,
+		sol.Column("lowered", sol.Generated(types.Varchar(32), sol.Function("lower", name))),
+	)
+	expect.SQL(
+		people.Create(),
+		`CREATE TABLE people (
+  name VARCHAR(32) COLLATE "C",
+  lowered VARCHAR(32) GENERATED ALWAYS AS (lower(name)) STORED
+);`,
+	)
+	expect.Error(Table("virtual_people",
+		name,
+		sol.Column("lowered", sol.Generated(
+			types.Varchar(32), sol.Function("lower", name),
+		).Virtual()),
+	).Create())
+	// Comments are separate statements
+	events := Table("events",
+		sol.Column("payload", JSON()).Comment("Raw payload"),
+		sol.Comment("Incoming events"),
+		Unlogged(),
+	)
+	expect.SQL(
+		events.Create(),
+		`CREATE UNLOGGED TABLE events (
+  payload json
+);`,
+	)
+	comments := events.Create().Comments(&PostGres{})
+	require.Equal(t, 2, len(comments))
+	expect.SQL(comments[0], `COMMENT ON TABLE events IS 'Incoming events';`)
+	expect.SQL(comments[1], `COMMENT ON COLUMN events.payload IS 'Raw payload';`)
 }
 
 // TestPostGres_Select tests a variety of SelectStmt features against the
@@ -260,3 +334,69 @@ func TestPostGres_Transaction(t *testing.T) {
 	require.Nil(t, conn.Query(things.Select(), &fifth))
 	assert.Equal(t, 2, len(fifth), "Thing E should have been committed")
 }
+
+// TestPostGres_InsertID tests that generated ids are returned into the
+// inserted structs
+func TestPostGres_InsertID(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+	items := Table("items_auto",
+		sol.Column("id", types.AutoIncrement()),
+		sol.Column("name", types.Varchar()),
+	)
+	expect.SQL(
+		items.Create(),
+		`CREATE TABLE items_auto (
+  id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name VARCHAR
+);`,
+	)
+
+	conn := getConn(t) // TODO close
+	tx, err := conn.Begin()
+	require.Nil(t, err, "Creating a new transaction should not error")
+	defer tx.Rollback()
+	require.Nil(t, tx.Query(items.Create().Temporary()))
+
+	many := []item{{Name: "a"}, {Name: "b"}}
+	require.Nil(t, sol.InsertID(tx, items, many))
+	assert.Equal(t, uint64(1), many[0].ID)
+	assert.Equal(t, uint64(2), many[1].ID)
+
+	// Serial columns also return their ids
+	require.Nil(t, tx.Query(itemsB.Create().Temporary()))
+	one := item{Name: "c"}
+	require.Nil(t, sol.InsertID(tx, itemsB, &one))
+	assert.Equal(t, uint64(1), one.ID)
+}
+
+// TestPostGres_MetaData tests that tables which reference each other are
+// created and dropped within a transaction
+func TestPostGres_MetaData(t *testing.T) {
+	teams := Table("teams_meta",
+		sol.Column("id", types.Integer()),
+		sol.Column("captain_id", types.Integer()),
+		sol.PrimaryKey("id"),
+	)
+	players := Table("players_meta",
+		sol.Column("id", types.Integer()),
+		sol.ForeignKey("team_id", teams),
+		sol.PrimaryKey("id"),
+	)
+	require.Nil(t, sol.ForeignKeyConstraint(
+		[]string{"captain_id"}, players, nil,
+	).Deferrable().Modify(teams))
+	schema := sol.NewMetaData(teams, players)
+
+	conn := getConn(t) // TODO close
+	tx, err := conn.Begin()
+	require.Nil(t, err, "Creating a new transaction should not error")
+	defer tx.Rollback()
+	require.Nil(t, schema.CreateAll(tx))
+	require.Nil(t, schema.CreateAll(tx), "existing foreign keys should be skipped")
+
+	require.Nil(t, tx.Query(teams.Insert().Values(sol.Values{"id": 1, "captain_id": 2})))
+	require.Nil(t, tx.Query(players.Insert().Values(sol.Values{"id": 2, "team_id": 1})))
+
+	require.Nil(t, schema.DropAll(tx))
+	require.Nil(t, schema.DropAll(tx))
+}
@@ -1,9 +1,26 @@
 package postgres
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aodin/sol"
 	"github.com/aodin/sol/dialect"
 )
 
+const (
+	LOWER     = "lower"
+	UPPER     = "upper"
+	ISEMPTY   = "isempty"
+	LOWERINF  = "lower_inf"
+	UPPERINF  = "upper_inf"
+	emptyText = "empty"
+)
+
 type rangeType struct {
 	name      string
 	isNotNull bool
@@ -21,6 +38,11 @@ func (t rangeType) Create(d dialect.Dialect) (string, error) {
 	return compiled, nil
 }
 
+// IsNotNull returns true if the rangeType is NOT NULL
+func (t rangeType) IsNotNull() bool {
+	return t.isNotNull
+}
+
 func (t rangeType) NotNull() rangeType {
 	t.isNotNull = true
 	return t
@@ -60,3 +82,217 @@ func DateRange() (t rangeType) {
 	t.name = "daterange"
 	return
 }
+
+// RangeBound is the constraint of the types that can bound a Range:
+// int32 for int4range, int64 for int8range, float64 for numrange, and
+// time.Time for tsrange, tstzrange and daterange.
+type RangeBound interface {
+	int32 | int64 | float64 | time.Time
+}
+
+// Range is the value of a range column. Its bounds are inclusive or
+// exclusive, and either end can be infinite (unbounded), in which case
+// its value is ignored.
+//
+// Time bounds are sent with their offset, which tsrange discards: the
+// wall clock time is stored and then scanned as UTC. Bounds of tsrange
+// columns should be in UTC; tstzrange preserves the instant of any zone.
+type Range[T RangeBound] struct {
+	Lower, Upper                   T
+	LowerInclusive, UpperInclusive bool
+	LowerInfinite, UpperInfinite   bool
+	Empty                          bool
+}
+
+var _ driver.Valuer = Range[int64]{}
+var _ sql.Scanner = &Range[int64]{}
+
+// NewRange creates the range [lower, upper), which is the canonical form
+// of discrete ranges in postgres
+func NewRange[T RangeBound](lower, upper T) Range[T] {
+	return Range[T]{Lower: lower, Upper: upper, LowerInclusive: true}
+}
+
+// EmptyRange creates a range that contains nothing
+func EmptyRange[T RangeBound]() Range[T] {
+	return Range[T]{Empty: true}
+}
+
+// String returns the range in postgres range input syntax, e.g. [1,5)
+func (r Range[T]) String() string {
+	if r.Empty {
+		return emptyText
+	}
+	lower := "("
+	if r.LowerInclusive && !r.LowerInfinite {
+		lower = "["
+	}
+	if !r.LowerInfinite {
+		lower += formatBound(r.Lower)
+	}
+	upper := ")"
+	if r.UpperInclusive && !r.UpperInfinite {
+		upper = "]"
+	}
+	if !r.UpperInfinite {
+		upper = formatBound(r.Upper) + upper
+	}
+	return lower + "," + upper
+}
+
+// Value implements the driver.Valuer interface
+func (r Range[T]) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface. NULL is scanned as the zero
+// value of the Range.
+func (r *Range[T]) Scan(src interface{}) error {
+	var text string
+	switch value := src.(type) {
+	case nil:
+		*r = Range[T]{}
+		return nil
+	case []byte:
+		text = string(value)
+	case string:
+		text = value
+	default:
+		return fmt.Errorf("postgres: unable to scan %T into a Range", src)
+	}
+
+	*r = Range[T]{}
+	if text == emptyText {
+		r.Empty = true
+		return nil
+	}
+	if len(text) < 3 {
+		return fmt.Errorf("postgres: invalid range %q", text)
+	}
+	first, last := text[0], text[len(text)-1]
+	if (first != '[' && first != '(') || (last != ']' && last != ')') {
+		return fmt.Errorf("postgres: invalid range %q", text)
+	}
+	lower, upper, ok := splitRange(text[1 : len(text)-1])
+	if !ok {
+		return fmt.Errorf("postgres: invalid range %q", text)
+	}
+
+	var err error
+	if r.LowerInfinite, err = parseBound(lower, &r.Lower); err != nil {
+		return err
+	}
+	if r.UpperInfinite, err = parseBound(upper, &r.Upper); err != nil {
+		return err
+	}
+	r.LowerInclusive = first == '[' && !r.LowerInfinite
+	r.UpperInclusive = last == ']' && !r.UpperInfinite
+	return nil
+}
+
+// formatBound returns the given bound as range input, quoting times
+func formatBound(bound interface{}) string {
+	switch value := bound.(type) {
+	case int32:
+		return strconv.FormatInt(int64(value), 10)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case time.Time:
+		return fmt.Sprintf(`"%s"`, value.Format("2006-01-02 15:04:05.999999999-07:00"))
+	}
+	return fmt.Sprint(bound)
+}
+
+// timeLayouts are the formats of time bounds output by postgres
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// parseBound parses the given range output into the bound. It returns
+// true if the bound is infinite.
+func parseBound(text string, bound interface{}) (bool, error) {
+	if text == "" || text == "infinity" || text == "-infinity" {
+		return true, nil
+	}
+	var err error
+	switch dest := bound.(type) {
+	case *int32:
+		var value int64
+		value, err = strconv.ParseInt(text, 10, 32)
+		*dest = int32(value)
+	case *int64:
+		*dest, err = strconv.ParseInt(text, 10, 64)
+	case *float64:
+		*dest, err = strconv.ParseFloat(text, 64)
+	case *time.Time:
+		for _, layout := range timeLayouts {
+			if *dest, err = time.Parse(layout, text); err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("postgres: invalid range bound %q: %s", text, err)
+	}
+	return false, nil
+}
+
+// splitRange separates the bounds of a range, removing any quotes
+func splitRange(text string) (string, string, bool) {
+	var bounds []string
+	var bound strings.Builder
+	var quoted, escaped bool
+	for _, c := range text {
+		switch {
+		case escaped:
+			bound.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			bounds = append(bounds, bound.String())
+			bound.Reset()
+		default:
+			bound.WriteRune(c)
+		}
+	}
+	bounds = append(bounds, bound.String())
+	if len(bounds) != 2 {
+		return "", "", false
+	}
+	return bounds[0], bounds[1], true
+}
+
+// Lower returns a range column wrapped in the lower() function
+func Lower(col sol.Columnar) ColumnElem {
+	return ColumnElem{sol.Function(LOWER, col)}
+}
+
+// Upper returns a range column wrapped in the upper() function
+func Upper(col sol.Columnar) ColumnElem {
+	return ColumnElem{sol.Function(UPPER, col)}
+}
+
+// IsEmpty returns a range column wrapped in the isempty() function,
+// which can be used as a conditional clause
+//  meetings.Select().Where(postgres.IsEmpty(meetings.C("time")))
+func IsEmpty(col sol.Columnar) ColumnElem {
+	return ColumnElem{sol.Function(ISEMPTY, col)}
+}
+
+// LowerInf returns a range column wrapped in the lower_inf() function
+func LowerInf(col sol.Columnar) ColumnElem {
+	return ColumnElem{sol.Function(LOWERINF, col)}
+}
+
+// UpperInf returns a range column wrapped in the upper_inf() function
+func UpperInf(col sol.Columnar) ColumnElem {
+	return ColumnElem{sol.Function(UPPERINF, col)}
+}
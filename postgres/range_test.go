@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/types"
+)
+
+var bookings = Table("bookings",
+	sol.Column("room", types.Integer().NotNull()),
+	sol.Column("during", TimestampRange().NotNull()),
+	sol.Column("seats", Int4Range()),
+	Exclude(With("room", sol.Equal), With("during", Overlap)),
+)
+
+type booking struct {
+	Room   int64
+	During Range[time.Time]
+	Seats  Range[int32]
+}
+
+func TestRange(t *testing.T) {
+	jan1 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan2 := jan1.AddDate(0, 0, 1)
+
+	// Ranges are sent in the range input syntax
+	for want, value := range map[string]interface{}{
+		"[1,5)":      NewRange[int32](1, 5),
+		"(1.5,2.25]": Range[float64]{Lower: 1.5, Upper: 2.25, UpperInclusive: true},
+		"(,5]":       Range[int64]{Upper: 5, LowerInfinite: true, UpperInclusive: true},
+		"[1,)":       Range[int64]{Lower: 1, LowerInclusive: true, UpperInfinite: true},
+		"(,)":        Range[int64]{LowerInfinite: true, UpperInfinite: true},
+		"empty":      EmptyRange[int64](),
+		`["2017-01-01 00:00:00+00:00","2017-01-02 00:00:00+00:00")`: NewRange(jan1, jan2),
+	} {
+		valuer := value.(interface{ String() string })
+		assert.Equal(t, want, valuer.String())
+	}
+
+	var ints Range[int32]
+	require.Nil(t, ints.Scan([]byte("[1,5)")))
+	assert.Equal(t, NewRange[int32](1, 5), ints)
+	require.Nil(t, ints.Scan("empty"))
+	assert.True(t, ints.Empty)
+	require.Nil(t, ints.Scan("(,5]"))
+	assert.Equal(t, Range[int32]{Upper: 5, LowerInfinite: true, UpperInclusive: true}, ints)
+	assert.NotNil(t, ints.Scan("[1,5"))
+	assert.NotNil(t, ints.Scan("[a,5)"))
+	assert.NotNil(t, ints.Scan("[1,99999999999)"))
+	assert.NotNil(t, ints.Scan(5))
+	require.Nil(t, ints.Scan(nil))
+	assert.Equal(t, Range[int32]{}, ints, "NULL should reset the range")
+
+	var times Range[time.Time]
+	require.Nil(t, times.Scan(`["2017-01-01 00:00:00","2017-01-02 00:00:00")`))
+	assert.Equal(t, NewRange(jan1, jan2), times)
+	require.Nil(t, times.Scan(`["2017-01-01 00:00:00+00","2017-01-02 00:00:00+00")`))
+	assert.True(t, times.Lower.Equal(jan1) && times.Upper.Equal(jan2))
+	require.Nil(t, times.Scan(`[2017-01-01,infinity)`))
+	assert.True(t, times.Lower.Equal(jan1) && times.UpperInfinite)
+
+	var floats Range[float64]
+	require.Nil(t, floats.Scan(`(1.5,2.25]`))
+	assert.Equal(t, Range[float64]{Lower: 1.5, Upper: 2.25, UpperInclusive: true}, floats)
+}
+
+func TestRange_functions(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+	during := bookings.C("during")
+
+	expect.SQL(
+		sol.Select(Lower(during), Upper(during)).Where(
+			IsEmpty(during),
+			UpperInf(during),
+		).OrderBy(LowerInf(during)),
+		`SELECT lower(bookings.during), upper(bookings.during) FROM bookings WHERE (isempty(bookings.during) AND upper_inf(bookings.during)) ORDER BY lower_inf(bookings.during)`,
+	)
+
+	expect.SQL(
+		bookings.Select().Where(during.Overlap(NewRange[int64](1, 5))),
+		`SELECT bookings.room, bookings.during, bookings.seats FROM bookings WHERE bookings.during && $1`,
+		NewRange[int64](1, 5),
+	)
+}
+
+func TestExclude(t *testing.T) {
+	expect := sol.NewTester(t, &PostGres{})
+
+	expect.SQL(
+		bookings.Create(),
+		`CREATE TABLE bookings (
+  room INTEGER NOT NULL,
+  during tsrange NOT NULL,
+  seats int4range,
+  EXCLUDE USING gist (room WITH =, during WITH &&)
+);`,
+	)
+
+	compiled, err := Exclude(With("seats", Overlap)).Using("spgist").Create(&PostGres{})
+	require.Nil(t, err)
+	assert.Equal(t, "EXCLUDE USING spgist (seats WITH &&)", compiled)
+
+	assert.Panics(t, func() {
+		Table("invalid",
+			sol.Column("during", TimestampRange()),
+			Exclude(With("missing", Overlap)),
+		)
+	})
+	assert.Panics(t, func() {
+		Table("invalid", sol.Column("during", TimestampRange()), Exclude())
+	})
+}
+
+// TestPostGres_Range tests the sending and scanning of ranges and the
+// EXCLUDE constraint against the postgres database
+func TestPostGres_Range(t *testing.T) {
+	conn := getConn(t) // TODO close
+	tx, err := conn.Begin()
+	require.Nil(t, err, "Creating a new transaction should not error")
+	defer tx.Rollback()
+
+	require.Nil(t, tx.Query(sol.Text(`CREATE EXTENSION IF NOT EXISTS btree_gist`)))
+	require.Nil(t, tx.Query(bookings.Create().Temporary().IfNotExists()))
+
+	jan1 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := booking{
+		Room:   1,
+		During: NewRange(jan1, jan1.Add(time.Hour)),
+		Seats:  NewRange[int32](1, 10),
+	}
+	require.Nil(t, tx.Query(bookings.Insert().Values(a)))
+
+	var selected booking
+	require.Nil(t, tx.Query(bookings.Select(), &selected))
+	assert.Equal(t, a.Seats, selected.Seats)
+	assert.True(t, selected.During.Lower.Equal(a.During.Lower))
+	assert.True(t, selected.During.Upper.Equal(a.During.Upper))
+
+	var lower int32
+	require.Nil(t, tx.Query(sol.Select(Lower(bookings.C("seats"))), &lower))
+	assert.Equal(t, int32(1), lower)
+
+	// Overlapping bookings of the same room are excluded
+	b := a
+	b.During = NewRange(jan1.Add(30*time.Minute), jan1.Add(2*time.Hour))
+	assert.NotNil(t, tx.Query(bookings.Insert().Values(b)))
+}
@@ -21,4 +21,25 @@ func TestSelect(t *testing.T) {
 		),
 		`SELECT things.name, MAX(things.created_at) FROM things GROUP BY things.name ORDER BY MAX(things.created_at) DESC`,
 	)
+
+	// DISTINCT ON
+	expect.SQL(
+		sol.Select(things.C("name"), things.C("created_at")).Distinct(
+			things.C("name"),
+		).OrderBy(things.C("name"), things.C("created_at").Desc()),
+		`SELECT DISTINCT ON (things.name) things.name, things.created_at FROM things ORDER BY things.name, things.created_at DESC`,
+	)
+
+	// Aliased postgres tables keep their alias when joined
+	fk := &TableElem{TableElem: itemsFK.As("fk")}
+	expect.SQL(
+		itemsB.Select().InnerJoin(fk, fk.C("id").Equals(itemsB.C("id"))),
+		`SELECT items_b.id, items_b.name FROM items_b INNER JOIN items_fk AS fk ON fk.id = items_b.id`,
+	)
+
+	// Row locks
+	expect.SQL(
+		things.Select().Limit(1).ForNoKeyUpdate().Of(things).SkipLocked(),
+		`SELECT things.name, things.created_at FROM things LIMIT 1 FOR NO KEY UPDATE OF things SKIP LOCKED`,
+	)
 }
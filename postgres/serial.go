@@ -13,6 +13,7 @@ type serial struct {
 
 // serial must implement the Type interface
 var _ types.Type = serial{}
+var _ types.AutoIncrementer = serial{}
 
 func (t serial) Create(d dialect.Dialect) (string, error) {
 	compiled := t.name + " NOT NULL"
@@ -22,6 +23,23 @@ func (t serial) Create(d dialect.Dialect) (string, error) {
 	return compiled, nil
 }
 
+// AutoIncrements returns true since serial values are generated by the
+// database
+func (t serial) AutoIncrements() bool {
+	return true
+}
+
+// HasDefault returns true since serial columns default to the next
+// value of their sequence
+func (t serial) HasDefault() bool {
+	return true
+}
+
+// IsNotNull returns true since serial columns are always NOT NULL
+func (t serial) IsNotNull() bool {
+	return true
+}
+
 // Unique sets the serial type as unique
 func (t serial) Unique() serial {
 	t.isUnique = true
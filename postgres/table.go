@@ -24,6 +24,11 @@ func (table TableElem) C(name string) ColumnElem {
 	return table.Column(name)
 }
 
+// Copy creates a postgres.CopyStmt from the table
+func (table *TableElem) Copy(columns ...string) CopyStmt {
+	return Copy(table, columns...)
+}
+
 // Insert creates a postgres.InsertStmt from the table
 func (table *TableElem) Insert() InsertStmt {
 	return Insert(table)
@@ -33,3 +38,9 @@ func (table *TableElem) Insert() InsertStmt {
 func Table(name string, modifiers ...sol.Modifier) *TableElem {
 	return &TableElem{TableElem: sol.Table(name, modifiers...)}
 }
+
+// Unlogged creates the table without writing to the write-ahead log,
+// which is faster, but its data will be lost after a crash
+func Unlogged() sol.TableOption {
+	return sol.Qualifier("UNLOGGED")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/aodin/sol/dialect"
+	"github.com/aodin/sol/types"
 )
 
 const (
@@ -19,6 +20,9 @@ type timestamp struct {
 	defaultValue string // TODO Additional defaults?
 }
 
+var _ types.Type = timestamp{}
+var _ types.Defaulter = timestamp{}
+
 func (t timestamp) Create(d dialect.Dialect) (string, error) {
 	compiled := t.name
 	if t.withTimezone {
@@ -41,6 +45,16 @@ func (t timestamp) Default(value string) timestamp {
 	return t
 }
 
+// HasDefault returns true if the column has a DEFAULT
+func (t timestamp) HasDefault() bool {
+	return t.defaultValue != ""
+}
+
+// IsNotNull returns true if the timestamp is NOT NULL
+func (t timestamp) IsNotNull() bool {
+	return t.isNotNull
+}
+
 func (t timestamp) NotNull() timestamp {
 	t.isNotNull = true
 	return t
@@ -19,6 +19,7 @@ type uuid struct {
 
 // uuid must implement the Type interface
 var _ types.Type = uuid{}
+var _ types.Defaulter = uuid{}
 
 func (t uuid) Create(d dialect.Dialect) (string, error) {
 	compiled := t.name
@@ -39,6 +40,16 @@ func (t uuid) Default(value string) uuid {
 	return t
 }
 
+// HasDefault returns true if the column has a DEFAULT
+func (t uuid) HasDefault() bool {
+	return t.defaultValue != ""
+}
+
+// IsNotNull returns true if the uuid is NOT NULL
+func (t uuid) IsNotNull() bool {
+	return t.isNotNull
+}
+
 func (t uuid) NotNull() uuid {
 	t.isNotNull = true
 	return t
@@ -47,6 +47,11 @@ func (pk PKArray) Modify(tabular Tabular) error {
 			)
 		}
 	}
+	if len(table.pk) > 0 {
+		return fmt.Errorf(
+			"sol: table '%s' already has a primary key", table.name,
+		)
+	}
 	table.pk = pk
 
 	// Add the pk to the create array
@@ -54,6 +59,12 @@ func (pk PKArray) Modify(tabular Tabular) error {
 	return nil
 }
 
+// Named returns the primary key as a named constraint
+//  sol.PrimaryKey("a", "b").Named("pairs_pkey")
+func (pk PKArray) Named(name string) NamedConstraint {
+	return NamedConstraint{name: name, constraint: pk}
+}
+
 // PrimaryKey creates a new PKArray. Only one primary key is allowed
 // per table.
 func PrimaryKey(names ...string) PKArray {
@@ -0,0 +1,301 @@
+// Package queue implements a job queue on top of a sol table.
+//
+// Workers claim jobs with SELECT ... FOR UPDATE SKIP LOCKED, which allows
+// many workers to share a postgres or MySQL 8 queue without blocking each
+// other. A claimed job is hidden from other workers until its visibility
+// timeout expires, so the jobs of crashed workers will be retried. Failed
+// jobs are retried with backoff until they reach their maximum attempts,
+// after which they are marked dead and kept for inspection.
+//
+// Since sqlite3 does not support row locks, its claims rely on the
+// database's write lock instead. Open the database with _txlock=immediate
+// so that transactions begin with BEGIN IMMEDIATE and concurrent workers
+// will wait for each other rather than fail.
+package queue
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/types"
+)
+
+// ErrEmpty is returned by Claim when no jobs are ready
+var ErrEmpty = errors.New("queue: no jobs are ready")
+
+// Jobs is the default jobs table
+var Jobs = Table("jobs")
+
+// Table declares a jobs table with the given name. Queues of different
+// names can share a single table.
+func Table(name string) *sol.TableElem {
+	return sol.Table(name,
+		sol.Column("id", types.Varchar().Limit(32).NotNull()),
+		sol.Column("queue", types.Varchar().Limit(64).NotNull()),
+		sol.Column("payload", types.Text().NotNull()),
+		sol.Column("attempts", types.Integer().NotNull()),
+		sol.Column("max_attempts", types.Integer().NotNull()),
+		sol.Column("run_at", types.Timestamp().NotNull()),
+		sol.Column("last_error", types.Text().NotNull()),
+		sol.Column("dead", types.Boolean().NotNull()),
+		sol.Column("created_at", types.Timestamp().NotNull()),
+		sol.PrimaryKey("id"),
+	)
+}
+
+// Job is a single row of the jobs table. RunAt is the time the job will
+// be visible to workers.
+type Job struct {
+	ID          string
+	Queue       string
+	Payload     string
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LastError   string
+	Dead        bool
+	CreatedAt   time.Time
+}
+
+// Backoff returns the delay before a job is retried after the given
+// number of attempts
+type Backoff func(attempts int) time.Duration
+
+// Exponential creates a Backoff that doubles the base delay after each
+// attempt, up to the given maximum
+func Exponential(base, max time.Duration) Backoff {
+	return func(attempts int) time.Duration {
+		delay := base
+		for i := 1; i < attempts; i++ {
+			if delay >= max/2 {
+				return max
+			}
+			delay *= 2
+		}
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+}
+
+// Queue is a named queue of jobs. Its methods return a copy of the queue
+// with the new configuration.
+type Queue struct {
+	name        string
+	table       *sol.TableElem
+	timeout     time.Duration
+	maxAttempts int
+	backoff     Backoff
+	poll        time.Duration
+	now         func() time.Time
+}
+
+// New creates a queue with the given name that uses the default Jobs table.
+// By default, jobs have a visibility timeout of five minutes and are
+// attempted five times with exponential backoff.
+func New(name string) Queue {
+	return Queue{
+		name:        name,
+		table:       Jobs,
+		timeout:     5 * time.Minute,
+		maxAttempts: 5,
+		backoff:     Exponential(time.Second, time.Hour),
+		poll:        time.Second,
+		now:         time.Now,
+	}
+}
+
+// Backoff sets the delay between retries of failed jobs
+func (q Queue) Backoff(backoff Backoff) Queue {
+	q.backoff = backoff
+	return q
+}
+
+// MaxAttempts sets the number of attempts of new jobs before they are
+// marked dead
+func (q Queue) MaxAttempts(n int) Queue {
+	q.maxAttempts = n
+	return q
+}
+
+// Poll sets how long Work will wait to claim another job after the
+// queue was empty
+func (q Queue) Poll(interval time.Duration) Queue {
+	q.poll = interval
+	return q
+}
+
+// Table sets the jobs table of the queue, which should be declared
+// with Table
+func (q Queue) Table(table *sol.TableElem) Queue {
+	q.table = table
+	return q
+}
+
+// Timeout sets the visibility timeout: how long a claimed job is hidden
+// from other workers before it is assumed lost and claimed again
+func (q Queue) Timeout(timeout time.Duration) Queue {
+	q.timeout = timeout
+	return q
+}
+
+// Enqueue adds a job with the given payload that is ready immediately
+func (q Queue) Enqueue(conn sol.Conn, payload string) (Job, error) {
+	return q.EnqueueAt(conn, payload, q.now())
+}
+
+// EnqueueAt adds a job with the given payload that will be ready at the
+// given time
+func (q Queue) EnqueueAt(conn sol.Conn, payload string, at time.Time) (Job, error) {
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+	job := Job{
+		ID:          id,
+		Queue:       q.name,
+		Payload:     payload,
+		MaxAttempts: q.maxAttempts,
+		RunAt:       at.UTC(),
+		CreatedAt:   q.now().UTC(),
+	}
+	return job, conn.Query(q.table.Insert().Values(job))
+}
+
+// Claim returns the next ready job and hides it from other workers until
+// its visibility timeout expires. The claim is made within a transaction,
+// which will be committed unless the connection is already a transaction.
+// Jobs whose final attempt timed out will be marked dead. ErrEmpty is
+// returned if no jobs are ready.
+func (q Queue) Claim(conn sol.Conn) (job Job, err error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return job, err
+	}
+	// Only close the transaction if it was started here. An empty queue
+	// is committed, since jobs may have been marked dead.
+	if _, isTX := conn.(sol.TX); !isTX {
+		defer func() {
+			if err == nil || err == ErrEmpty {
+				tx.IsSuccessful()
+			}
+			if closeErr := tx.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	now := q.now().UTC()
+	for {
+		job = Job{}
+		if err = tx.Query(q.next(now), &job); err != nil {
+			if err == sql.ErrNoRows {
+				err = ErrEmpty
+			}
+			return job, err
+		}
+		if job.Attempts < job.MaxAttempts {
+			break
+		}
+		job.Dead = true
+		job.LastError = fmt.Sprintf(
+			"queue: timed out after %d attempts", job.Attempts,
+		)
+		if err = tx.Query(q.update(job, sol.Values{
+			"dead":       job.Dead,
+			"last_error": job.LastError,
+		})); err != nil {
+			return job, err
+		}
+	}
+
+	claimed := job
+	claimed.Attempts += 1
+	claimed.RunAt = now.Add(q.timeout)
+	if err = tx.Query(q.update(job, sol.Values{
+		"attempts": claimed.Attempts,
+		"run_at":   claimed.RunAt,
+	})); err != nil {
+		return job, err
+	}
+	return claimed, nil
+}
+
+// Complete deletes a claimed job. It has no effect if the job's
+// visibility timeout expired and it was claimed again.
+func (q Queue) Complete(conn sol.Conn, job Job) error {
+	return conn.Query(q.table.Delete().Where(
+		q.table.C("id").Equals(job.ID),
+		q.table.C("attempts").Equals(job.Attempts),
+	))
+}
+
+// Fail records the error of a claimed job and retries it after the
+// queue's backoff. Jobs that have reached their maximum attempts will
+// be marked dead instead. It has no effect if the job's visibility
+// timeout expired and it was claimed again.
+func (q Queue) Fail(conn sol.Conn, job Job, cause error) error {
+	values := sol.Values{"last_error": cause.Error()}
+	if job.Attempts >= job.MaxAttempts {
+		values["dead"] = true
+	} else {
+		values["run_at"] = q.now().UTC().Add(q.backoff(job.Attempts))
+	}
+	return conn.Query(q.update(job, values))
+}
+
+// Dead returns the dead jobs of the queue, oldest first
+func (q Queue) Dead(conn sol.Conn) ([]Job, error) {
+	var jobs []Job
+	err := conn.Query(q.table.Select().Where(
+		q.table.C("queue").Equals(q.name),
+		q.table.C("dead").Equals(true),
+	).OrderBy(q.table.C("created_at"), q.table.C("id")), &jobs)
+	return jobs, err
+}
+
+// Requeue makes a dead job ready again with its attempts reset
+func (q Queue) Requeue(conn sol.Conn, job Job) error {
+	return conn.Query(q.table.Update().Values(sol.Values{
+		"attempts": 0,
+		"dead":     false,
+		"run_at":   q.now().UTC(),
+	}).Where(
+		q.table.C("id").Equals(job.ID),
+		q.table.C("dead").Equals(true),
+	))
+}
+
+// next selects and locks the next ready job of the queue
+func (q Queue) next(now time.Time) sol.SelectStmt {
+	return q.table.Select().Where(
+		q.table.C("queue").Equals(q.name),
+		q.table.C("dead").Equals(false),
+		q.table.C("run_at").LTE(now),
+	).OrderBy(
+		q.table.C("run_at"), q.table.C("id"),
+	).Limit(1).ForUpdate().SkipLocked().IgnoreUnsupportedLock()
+}
+
+// update sets the given values of a job if it has not been claimed again
+func (q Queue) update(job Job, values sol.Values) sol.UpdateStmt {
+	return q.table.Update().Values(values).Where(
+		q.table.C("id").Equals(job.ID),
+		q.table.C("attempts").Equals(job.Attempts),
+	)
+}
+
+// newID creates a random identifier for a job
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("queue: unable to create a job id: %s", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,189 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aodin/sol"
+	"github.com/aodin/sol/postgres"
+	"github.com/aodin/sol/sqlite3"
+)
+
+func TestExponential(t *testing.T) {
+	backoff := Exponential(time.Second, time.Minute)
+	for attempts, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		3:  4 * time.Second,
+		6:  32 * time.Second,
+		7:  time.Minute,
+		64: time.Minute,
+	} {
+		if have := backoff(attempts); have != want {
+			t.Errorf(
+				"Unexpected backoff after %d attempts: want %s, have %s",
+				attempts, want, have,
+			)
+		}
+	}
+}
+
+func TestQueue_next(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := New("emails").next(now)
+
+	sol.NewTester(t, postgres.Dialect()).SQL(
+		next,
+		`SELECT jobs.id, jobs.queue, jobs.payload, jobs.attempts, jobs.max_attempts, jobs.run_at, jobs.last_error, jobs.dead, jobs.created_at FROM jobs WHERE (jobs.queue = $1 AND jobs.dead = $2 AND jobs.run_at <= $3) ORDER BY jobs.run_at, jobs.id LIMIT 1 FOR UPDATE SKIP LOCKED`,
+		"emails", false, now,
+	)
+
+	// sqlite3 relies on its database lock instead
+	sol.NewTester(t, sqlite3.Dialect()).SQL(
+		next,
+		`SELECT jobs.id, jobs.queue, jobs.payload, jobs.attempts, jobs.max_attempts, jobs.run_at, jobs.last_error, jobs.dead, jobs.created_at FROM jobs WHERE (jobs.queue = ? AND jobs.dead = ? AND jobs.run_at <= ?) ORDER BY jobs.run_at, jobs.id LIMIT 1`,
+		"emails", false, now,
+	)
+}
+
+// TestQueue performs an integration test of the queue with an in-memory
+// sqlite3 database
+func TestQueue(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open connection: %s", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1) // Each connection has its own in-memory database
+
+	if err = conn.Query(Jobs.Create()); err != nil {
+		t.Fatalf("Creating the jobs table should not error: %s", err)
+	}
+
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := New("emails").MaxAttempts(2).Timeout(time.Minute).Backoff(
+		Exponential(time.Second, time.Hour),
+	)
+	q.now = func() time.Time { return now }
+
+	if _, err = q.Claim(conn); err != ErrEmpty {
+		t.Fatalf("Claim of an empty queue should return ErrEmpty: %v", err)
+	}
+
+	first, err := q.Enqueue(conn, "first")
+	if err != nil {
+		t.Fatalf("Enqueue should not error: %s", err)
+	}
+	if _, err = q.EnqueueAt(conn, "later", now.Add(time.Hour)); err != nil {
+		t.Fatalf("EnqueueAt should not error: %s", err)
+	}
+
+	// Other queues do not share jobs
+	if _, err = New("other").Claim(conn); err != ErrEmpty {
+		t.Errorf("Claim of another queue should return ErrEmpty: %v", err)
+	}
+
+	job, err := q.Claim(conn)
+	if err != nil {
+		t.Fatalf("Claim should not error: %s", err)
+	}
+	if job.ID != first.ID || job.Attempts != 1 {
+		t.Errorf("Unexpected claimed job: %+v", job)
+	}
+
+	// Claimed jobs are hidden until their visibility timeout expires
+	if _, err = q.Claim(conn); err != ErrEmpty {
+		t.Errorf("Claimed jobs should be hidden: %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	lost := job
+	if job, err = q.Claim(conn); err != nil || job.Attempts != 2 {
+		t.Fatalf("Timed out jobs should be claimed again: %+v, %v", job, err)
+	}
+
+	// Lost claims have no effect
+	if err = q.Complete(conn, lost); err != nil {
+		t.Fatalf("Complete should not error: %s", err)
+	}
+
+	// The final attempt is dead-lettered on failure
+	if err = q.Fail(conn, job, errors.New("bounced")); err != nil {
+		t.Fatalf("Fail should not error: %s", err)
+	}
+	dead, err := q.Dead(conn)
+	if err != nil {
+		t.Fatalf("Dead should not error: %s", err)
+	}
+	if len(dead) != 1 || dead[0].ID != first.ID || dead[0].LastError != "bounced" {
+		t.Fatalf("Unexpected dead jobs: %+v", dead)
+	}
+
+	// Requeued jobs are retried with backoff
+	if err = q.Requeue(conn, dead[0]); err != nil {
+		t.Fatalf("Requeue should not error: %s", err)
+	}
+	fail := func(job Job) error { return errors.New("failed") }
+	if processed, err := q.Process(conn, fail); !processed || err != nil {
+		t.Fatalf("Process should claim the requeued job: %t, %v", processed, err)
+	}
+	if processed, _ := q.Process(conn, fail); processed {
+		t.Errorf("Failed jobs should wait for their backoff")
+	}
+	now = now.Add(time.Second)
+	done := func(job Job) error { return nil }
+	if processed, err := q.Process(conn, done); !processed || err != nil {
+		t.Fatalf("Process should retry the failed job: %t, %v", processed, err)
+	}
+
+	// Later jobs are ready at their time
+	now = now.Add(time.Hour)
+	job, err = q.Claim(conn)
+	if err != nil || job.Payload != "later" {
+		t.Fatalf("Unexpected claimed job: %+v, %v", job, err)
+	}
+	if err = q.Complete(conn, job); err != nil {
+		t.Fatalf("Complete should not error: %s", err)
+	}
+	if _, err = q.Claim(conn); err != ErrEmpty {
+		t.Errorf("Completed jobs should be deleted: %v", err)
+	}
+}
+
+// TestQueue_timedOut tests that a job whose final attempt timed out is
+// marked dead even when no other jobs are ready
+func TestQueue_timedOut(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open connection: %s", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1) // Each connection has its own in-memory database
+
+	if err = conn.Query(Jobs.Create()); err != nil {
+		t.Fatalf("Creating the jobs table should not error: %s", err)
+	}
+
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := New("emails").MaxAttempts(1).Timeout(time.Minute)
+	q.now = func() time.Time { return now }
+
+	if _, err = q.Enqueue(conn, "lost"); err != nil {
+		t.Fatalf("Enqueue should not error: %s", err)
+	}
+	if _, err = q.Claim(conn); err != nil {
+		t.Fatalf("Claim should not error: %s", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err = q.Claim(conn); err != ErrEmpty {
+		t.Fatalf("Claim of a timed out final attempt should return ErrEmpty: %v", err)
+	}
+	dead, err := q.Dead(conn)
+	if err != nil {
+		t.Fatalf("Dead should not error: %s", err)
+	}
+	if len(dead) != 1 || dead[0].Payload != "lost" {
+		t.Errorf("Unexpected dead jobs: %+v", dead)
+	}
+}
@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/aodin/sol"
+)
+
+// Handler performs the work of a job. Returning an error will retry the
+// job after the queue's backoff until it is marked dead.
+type Handler func(Job) error
+
+// Process claims a single job and runs the handler on it, completing or
+// failing the job with the handler's result. It returns false if no jobs
+// were ready.
+func (q Queue) Process(conn sol.Conn, handler Handler) (bool, error) {
+	job, err := q.Claim(conn)
+	if err == ErrEmpty {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if cause := handler(job); cause != nil {
+		return true, q.Fail(conn, job, cause)
+	}
+	return true, q.Complete(conn, job)
+}
+
+// Work processes jobs until the context is done, waiting for the queue's
+// poll interval whenever it is empty. It returns the context's error, or
+// the first error from the database - handler errors are recorded on
+// their jobs. Multiple workers can share a queue.
+func (q Queue) Work(ctx context.Context, conn sol.Conn, handler Handler) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		processed, err := q.Process(conn, handler)
+		if err != nil {
+			return err
+		}
+		if processed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(q.poll):
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+
+	"github.com/aodin/sol/dialect"
 )
 
 // Scanner is used for building mock result rows for testing
@@ -17,7 +19,8 @@ type Scanner interface {
 
 // Result is returned by a database query - it embeds a Scanner
 type Result struct {
-	stmt string
+	stmt    string
+	dialect dialect.Dialect // Used for scanning dialect specific types
 	Scanner
 }
 
@@ -54,10 +57,6 @@ func (r Result) All(obj interface{}) error {
 	default: // TODO enumerate types?
 		return r.allNative(columns, elem, list)
 	}
-	// TODO Until types are enumerated above this return will never run
-	return fmt.Errorf(
-		"sol: unsupported destination type %T for Result.All", obj,
-	)
 }
 
 // allStruct scanes the results into a slice of struct types
@@ -87,7 +86,9 @@ func (r Result) allStruct(columns []string, elem reflect.Type, list reflect.Valu
 
 		for i, field := range aligned {
 			if field.Exists() {
-				dest[i] = newElem.FieldByIndex(field.Type.Index).Addr().Interface()
+				dest[i] = field.scanDest(r.dialect,
+					newElem.FieldByIndex(field.Type.Index).Addr().Interface(),
+				)
 			} else {
 				dest[i] = &dest[i] // Discard
 			}
@@ -150,7 +151,8 @@ func (r Result) allNative(columns []string, elem reflect.Type, list reflect.Valu
 	}
 	for r.Next() {
 		newElem := reflect.New(elem).Elem()
-		if err := r.Scan(newElem.Addr().Interface()); err != nil {
+		dest := arrayDest(r.dialect, newElem.Addr().Interface())
+		if err := r.Scan(dest); err != nil {
 			return fmt.Errorf("sol: error scanning native slice: %s", err)
 		}
 		list.Set(reflect.Append(list, newElem))
@@ -239,7 +241,9 @@ func (r Result) oneStruct(columns []string, elem reflect.Value, obj interface{})
 	}
 	for i, field := range aligned {
 		if field.Exists() {
-			dest[i] = elem.FieldByIndex(field.Type.Index).Addr().Interface()
+			dest[i] = field.scanDest(r.dialect,
+				elem.FieldByIndex(field.Type.Index).Addr().Interface(),
+			)
 		} else {
 			dest[i] = &dest[i] // Discard
 		}
@@ -259,7 +263,7 @@ func (r Result) oneNative(columns []string, elem reflect.Value) error {
 			elem.Kind(),
 		)
 	}
-	if err := r.Scan(elem.Addr().Interface()); err != nil {
+	if err := r.Scan(arrayDest(r.dialect, elem.Addr().Interface())); err != nil {
 		return fmt.Errorf("sol: error scanning native type: %s", err)
 	}
 	return r.Err() // Check for delayed scan errors
@@ -338,6 +338,39 @@ func TestResult_All(t *testing.T) {
 
 }
 
+func TestResult_allJoined(t *testing.T) {
+	type User struct {
+		ID   int64
+		Name string
+	}
+	type Contact struct {
+		ID    int64
+		Value string
+	}
+
+	// Both id columns should be scanned into their own struct
+	two := mockResult(2, "users__id", "name", "contacts__id", "value")
+	var have []struct {
+		User
+		Contact
+	}
+	if err := two.All(&have); err != nil {
+		t.Fatalf("Result.All should not error when scanning joins: %s", err)
+	}
+	if len(have) != 2 {
+		t.Fatalf("Unexpected length of joined results: %d != 2", len(have))
+	}
+	if have[1].User.ID != 2 || have[1].Contact.ID != 2 {
+		t.Errorf(
+			"Unexpected joined ids: users %d, contacts %d",
+			have[1].User.ID, have[1].Contact.ID,
+		)
+	}
+	if have[1].Name != mockStr || have[1].Value != mockStr {
+		t.Errorf("Unexpected joined values: %+v", have[1])
+	}
+}
+
 func TestResult_allNative(t *testing.T) {
 	single := mockResult(2, "int")
 	var have []int
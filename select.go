@@ -27,6 +27,8 @@ type SelectStmt struct {
 	distincts  ColumnSet
 	limit      int
 	offset     int
+	after      []interface{} // Keyset pagination values
+	lock       LockClause
 }
 
 // Since SELECT statements can be used in FROM clauses, SelectStmt must
@@ -45,6 +47,62 @@ func (stmt SelectStmt) As(alias string) SelectStmt {
 	return stmt
 }
 
+// compileDistinctOn compiles the expressions of a DISTINCT ON, which is
+// only supported by some dialects. Any ORDER BY must begin with them.
+func (stmt SelectStmt) compileDistinctOn(d dialect.Dialect, ps *Parameters) (string, error) {
+	if !dialect.DistinctOn(d) {
+		return "", FieldError{
+			Clause: DISTINCTON,
+			Msg: fmt.Sprintf(
+				"sol: the dialect %T does not support DISTINCT ON", d,
+			),
+		}
+	}
+
+	distincts := stmt.distincts.All()
+	on := make([]string, len(distincts))
+	keys := make([]string, len(distincts)) // Compiled without parameters
+	var err error
+	for i, column := range distincts {
+		if on[i], err = column.Compile(d, ps); err != nil {
+			return "", err
+		}
+		keys[i], _ = column.Compile(d, Params())
+	}
+
+	// The leftmost ORDER BY expressions must match the DISTINCT ON
+	// expressions, although their order may differ
+	for i, ord := range stmt.orderBy {
+		if i == len(distincts) {
+			break
+		}
+		expr, err := ord.inner.Compile(d, Params())
+		if err != nil {
+			return "", inClause(err, ORDERBY)
+		}
+		if !hasString(keys, expr) {
+			return "", FieldError{
+				Clause: DISTINCTON,
+				Msg: fmt.Sprintf(
+					"sol: ORDER BY must begin with the DISTINCT ON expressions (%s), received %s",
+					strings.Join(keys, ", "), expr,
+				),
+			}
+		}
+	}
+	return strings.Join(on, ", "), nil
+}
+
+// hasString returns true if the given string is in the slice
+func hasString(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
 // TODO create a TableSet type?
 func (stmt SelectStmt) compileTables(d dialect.Dialect, ps *Parameters) ([]string, error) {
 	names := make([]string, len(stmt.tables))
@@ -69,37 +127,60 @@ func (stmt SelectStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if stmt.isDistinct {
 		compiled = append(compiled, DISTINCT)
 		if stmt.distincts.Exists() {
-			compiled = append(compiled, fmt.Sprintf(
-				"ON (%s)", strings.Join(stmt.distincts.FullNames(), ", "),
-			))
+			on, err := stmt.compileDistinctOn(d, ps)
+			if err != nil {
+				return "", inClause(err, DISTINCTON)
+			}
+			compiled = append(compiled, fmt.Sprintf("ON (%s)", on))
 		}
 	}
 
 	selections, err := stmt.columns.Compile(d, ps)
 	if err != nil {
-		return "", nil
+		return "", inClause(err, SELECT)
 	}
 
 	tables, err := stmt.compileTables(d, ps)
 	if err != nil {
-		return "", nil
+		return "", inClause(err, FROM)
 	}
 	compiled = append(compiled, selections, FROM, strings.Join(tables, ", "))
 
 	if len(stmt.joins) != 0 {
+		// Joins without conditions are matched by foreign key against
+		// the selected tables and any previously joined tables
+		joined := append([]Tabular{}, stmt.tables...)
 		for _, j := range stmt.joins {
+			j, err := j.on(joined)
+			if err != nil {
+				return "", inClause(err, j.method)
+			}
 			jc, err := j.Compile(d, ps)
 			if err != nil {
-				return "", err
+				return "", inClause(err, j.method)
 			}
 			compiled = append(compiled, jc)
+			joined = append(joined, j.table)
 		}
 	}
 
-	if stmt.where != nil {
-		conditional, err := stmt.where.Compile(d, ps)
+	where := stmt.where
+	if stmt.after != nil {
+		keyset, err := stmt.keyset(d)
 		if err != nil {
-			return "", err
+			return "", inClause(err, WHERE)
+		}
+		if where != nil {
+			where = AllOf(where, keyset)
+		} else {
+			where = keyset
+		}
+	}
+
+	if where != nil {
+		conditional, err := where.Compile(d, ps)
+		if err != nil {
+			return "", inClause(err, WHERE)
 		}
 		compiled = append(compiled, WHERE, conditional)
 	}
@@ -107,7 +188,7 @@ func (stmt SelectStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if stmt.groupBy.Exists() {
 		cc, err := stmt.groupBy.Compile(d, ps)
 		if err != nil {
-			return "", err
+			return "", inClause(err, GROUPBY)
 		}
 		compiled = append(compiled, GROUPBY, cc)
 	}
@@ -115,7 +196,7 @@ func (stmt SelectStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if stmt.having != nil {
 		conditional, err := stmt.having.Compile(d, ps)
 		if err != nil {
-			return "", err
+			return "", inClause(err, HAVING)
 		}
 		compiled = append(compiled, HAVING, conditional)
 	}
@@ -123,7 +204,9 @@ func (stmt SelectStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if len(stmt.orderBy) > 0 {
 		order := make([]string, len(stmt.orderBy))
 		for i, ord := range stmt.orderBy {
-			order[i], _ = ord.Compile(d, ps)
+			if order[i], err = ord.Compile(d, ps); err != nil {
+				return "", inClause(err, ORDERBY)
+			}
 		}
 		compiled = append(compiled, ORDERBY, strings.Join(order, ", "))
 	}
@@ -136,6 +219,17 @@ func (stmt SelectStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 		compiled = append(compiled, OFFSET, fmt.Sprintf("%d", stmt.offset))
 	}
 
+	if err := stmt.lock.validate(stmt); err != nil {
+		return "", err
+	}
+	lock, err := stmt.lock.Compile(d, ps)
+	if err != nil {
+		return "", err
+	}
+	if lock != "" {
+		compiled = append(compiled, lock)
+	}
+
 	if stmt.alias != "" {
 		return fmt.Sprintf(
 			"(%s) AS %s",
@@ -177,7 +271,7 @@ func (stmt SelectStmt) Table() *TableElem {
 
 func (stmt SelectStmt) hasTable(name string) bool {
 	for _, table := range stmt.tables {
-		if table.Name() == name {
+		if tableReference(table) == name {
 			return true
 		}
 	}
@@ -190,6 +284,14 @@ func (stmt SelectStmt) From(tables ...Tabular) SelectStmt {
 	return stmt
 }
 
+// AliasDuplicates will alias any selected columns that share a name with
+// their table name, e.g. users.id AS "users__id", so that results can be
+// scanned into structs with fields for each table.
+func (stmt SelectStmt) AliasDuplicates() SelectStmt {
+	stmt.columns = stmt.columns.AliasDuplicates()
+	return stmt
+}
+
 // All removes the DISTINCT clause from the SELECT statement.
 func (stmt SelectStmt) All() SelectStmt {
 	stmt.isDistinct = false
@@ -218,31 +320,44 @@ func (stmt SelectStmt) join(table Tabular, method string, clauses ...Clause) Sel
 	return stmt
 }
 
+// NaturalJoin adds a NATURAL INNER JOIN ... clause to the SELECT statement.
+func (stmt SelectStmt) NaturalJoin(table Tabular) SelectStmt {
+	stmt.joins = append(
+		stmt.joins,
+		JoinClause{method: INNERJOIN, table: table, natural: true},
+	)
+	return stmt
+}
+
 // CrossJoin adds a CROSS JOIN ... clause to the SELECT statement.
 func (stmt SelectStmt) CrossJoin(table Tabular) SelectStmt {
 	return stmt.join(table, CROSSJOIN)
 }
 
 // InnerJoin adds an INNER JOIN ... ON ... clause to the SELECT statement.
-// If no clauses are given, it will assume the clause is NATURAL.
+// If no clauses are given, the condition will be created from the one
+// foreign key between the table and the statement's other tables.
 func (stmt SelectStmt) InnerJoin(table Tabular, clauses ...Clause) SelectStmt {
 	return stmt.join(table, INNERJOIN, clauses...)
 }
 
 // LeftOuterJoin adds a LEFT OUTER JOIN ... ON ... clause to the SELECT
-// statement. If no clauses are given, it will assume the clause is NATURAL.
+// statement. If no clauses are given, the condition will be created from
+// the one foreign key between the table and the statement's other tables.
 func (stmt SelectStmt) LeftOuterJoin(table Tabular, clauses ...Clause) SelectStmt {
 	return stmt.join(table, LEFTOUTERJOIN, clauses...)
 }
 
 // RightOuterJoin adds a RIGHT OUTER JOIN ... ON ... clause to the SELECT
-// statement. If no clauses are given, it will assume the clause is NATURAL.
+// statement. If no clauses are given, the condition will be created from
+// the one foreign key between the table and the statement's other tables.
 func (stmt SelectStmt) RightOuterJoin(table Tabular, clauses ...Clause) SelectStmt {
 	return stmt.join(table, RIGHTOUTERJOIN, clauses...)
 }
 
 // FullOuterJoin adds a FULL OUTER JOIN ... ON ... clause to the SELECT
-// statement. If no clauses are given, it will assume the clause is NATURAL.
+// statement. If no clauses are given, the condition will be created from
+// the one foreign key between the table and the statement's other tables.
 func (stmt SelectStmt) FullOuterJoin(table Tabular, clauses ...Clause) SelectStmt {
 	return stmt.join(table, FULLOUTERJOIN, clauses...)
 }
@@ -315,6 +430,51 @@ func (stmt SelectStmt) Offset(offset int) SelectStmt {
 	return stmt
 }
 
+// CountStmt is an alias for CountOf(stmt). It returns a statement that
+// counts the rows of the SELECT statement without its pagination.
+func (stmt SelectStmt) CountStmt() SelectStmt {
+	return CountOf(stmt)
+}
+
+// CountOf returns a SELECT COUNT(*) statement for the rows of the given
+// statement. Its ORDER BY, LIMIT, OFFSET, and keyset pagination will be
+// removed, as will any row lock. Statements with DISTINCT, GROUP BY,
+// HAVING, or aggregate columns, such as MAX(), will be counted as a
+// subquery. The count can be scanned into an integer with Result.One.
+//  var total int64
+//  conn.Query(sol.CountOf(stmt), &total)
+func CountOf(stmt SelectStmt) SelectStmt {
+	stmt.orderBy = nil
+	stmt.limit = 0
+	stmt.offset = 0
+	stmt.after = nil
+	stmt.lock = LockClause{}
+	if !stmt.isDistinct && !stmt.groupBy.Exists() && !stmt.isAggregate() {
+		stmt.columns = Columns(countAll())
+		stmt.alias = ""
+		return stmt
+	}
+
+	var count SelectStmt
+	count.tables = []Tabular{stmt.As("counted")}
+	count.columns = Columns(countAll())
+	return count
+}
+
+// isAggregate returns true if the statement combines its rows, either
+// with a HAVING clause or an aggregate column
+func (stmt SelectStmt) isAggregate() bool {
+	if stmt.having != nil {
+		return true
+	}
+	for _, column := range stmt.columns.All() {
+		if isAggregate(column) {
+			return true
+		}
+	}
+	return false
+}
+
 // SelectTable creates a SELECT statement from the given table and its
 // columns. Any additional selections will not have their table added to
 // the SelectStmt's tables field - they must be added manually or through
@@ -328,14 +488,16 @@ func SelectTable(table Tabular, selects ...Selectable) (stmt SelectStmt) {
 	// Add any additional selections
 	for _, selection := range selects {
 		if selection == nil {
-			stmt.AddMeta("sol: received a nil selectable in SelectTable()")
+			stmt.AddError(FieldError{
+				Kind:   NilSelectable,
+				Clause: SELECT,
+				Msg:    "sol: received a nil selectable in SelectTable()",
+			})
 			return
 		}
 		for _, column := range selection.Columns() {
 			if column.IsInvalid() {
-				stmt.AddMeta(
-					"sol: cannot select invalid column %s", column.FullName(),
-				)
+				stmt.AddError(unknownColumn(column, SELECT))
 				return
 			}
 			// Since selections do not need to be unique, any errors
@@ -351,7 +513,11 @@ func Select(selections ...Selectable) (stmt SelectStmt) {
 	columns := []ColumnElem{} // Holds columns until validated
 	for _, selection := range selections {
 		if selection == nil {
-			stmt.AddMeta("sol: received a nil selectable in Select()")
+			stmt.AddError(FieldError{
+				Kind:   NilSelectable,
+				Clause: SELECT,
+				Msg:    "sol: received a nil selectable in Select()",
+			})
 			return
 		}
 		columns = append(columns, selection.Columns()...)
@@ -364,7 +530,7 @@ func Select(selections ...Selectable) (stmt SelectStmt) {
 
 	for _, column := range columns {
 		if column.IsInvalid() {
-			stmt.AddMeta("sol: column %s does not exist", column.FullName())
+			stmt.AddError(unknownColumn(column, SELECT))
 			return
 		}
 		// Since selections do not need to be unique, any errors
@@ -372,7 +538,7 @@ func Select(selections ...Selectable) (stmt SelectStmt) {
 		stmt.columns, _ = stmt.columns.Add(column)
 
 		// Add the table to the stmt tables if it does not already exist
-		if !stmt.hasTable(column.Table().Name()) {
+		if !stmt.hasTable(column.Table().reference()) {
 			stmt.tables = append(stmt.tables, column.Table())
 		}
 	}
@@ -35,6 +35,14 @@ func TestSelect(t *testing.T) {
 		`SELECT users.email AS "Email" FROM users`,
 	)
 
+	// Alias duplicate column names with their table
+	expect.SQL(
+		Select(
+			users.C("id"), users.C("name"), contacts.C("id"), contacts.C("value"),
+		).AliasDuplicates(),
+		`SELECT users.id AS "users__id", users.name, contacts.id AS "contacts__id", contacts.value FROM users, contacts`,
+	)
+
 	// Add an ORDER BY
 	expect.SQL(
 		Select(users.C("email")).OrderBy(users.C("email").Desc()),
@@ -64,6 +72,33 @@ func TestSelect(t *testing.T) {
 		`SELECT DISTINCT ON (users.id, users.name) users.name FROM users`,
 	)
 
+	// DISTINCT ON compiles functions, but not aliases
+	expect.SQL(
+		Select(users.C("name")).Distinct(Date(users.C("created_at")).As("day")),
+		`SELECT DISTINCT ON (DATE(users.created_at)) users.name FROM users`,
+	)
+
+	// ORDER BY must begin with the DISTINCT ON expressions in any order
+	expect.SQL(
+		Select(users.C("name")).Distinct(
+			users.C("id"), users.C("name"),
+		).OrderBy(users.C("name"), users.C("id").Desc(), users.C("email")),
+		`SELECT DISTINCT ON (users.id, users.name) users.name FROM users ORDER BY users.name, users.id DESC, users.email`,
+	)
+	expect.Error(
+		Select(users.C("name")).Distinct(
+			users.C("id"),
+		).OrderBy(users.C("name"), users.C("id")),
+	)
+
+	// DISTINCT ON is only supported by some dialects
+	plain := NewTester(t, plainDialect{})
+	plain.Error(Select(users.C("name")).Distinct(users.C("name")))
+	plain.SQL(
+		Select(users.C("name")).Distinct(),
+		`SELECT DISTINCT users.name FROM users`,
+	)
+
 	// All is the default and will remove any existing Distinct clause
 	expect.SQL(
 		Select(users.C("name")).Distinct().All(),
@@ -121,3 +156,49 @@ func TestSelect(t *testing.T) {
 	// Select a column that doesn't exist
 	expect.Error(Select(users.C("what")))
 }
+
+func TestCountOf(t *testing.T) {
+	expect := NewTester(t, &defaultDialect{})
+
+	// Pagination is removed
+	expect.SQL(
+		users.Select().Where(users.C("name").Equals("admin")).OrderBy(
+			users.C("id"),
+		).Limit(20).Offset(40).CountStmt(),
+		`SELECT COUNT(*) FROM users WHERE users.name = $1`,
+		"admin",
+	)
+	expect.SQL(
+		CountOf(users.Select().OrderBy(users.C("id")).After(2)),
+		`SELECT COUNT(*) FROM users`,
+	)
+	expect.SQL(
+		CountOf(users.Select().InnerJoin(contacts)),
+		`SELECT COUNT(*) FROM users INNER JOIN contacts ON contacts.user_id = users.id`,
+	)
+
+	// DISTINCT and GROUP BY are counted as subqueries
+	expect.SQL(
+		CountOf(Select(users.C("name")).Distinct().Limit(1)),
+		`SELECT COUNT(*) FROM (SELECT DISTINCT users.name FROM users) AS counted`,
+	)
+	expect.SQL(
+		CountOf(
+			Select(contacts.C("user_id"), Count(contacts.C("id"))).GroupBy(
+				contacts.C("user_id"),
+			).Having(Count(contacts.C("id")).GreaterThan(1)),
+		),
+		`SELECT COUNT(*) FROM (SELECT contacts.user_id, COUNT(contacts.id) FROM contacts GROUP BY contacts.user_id HAVING COUNT(contacts.id) > $1) AS counted`,
+		1,
+	)
+
+	// Aggregates without GROUP BY return a single row
+	expect.SQL(
+		CountOf(Select(Max(users.C("id")))),
+		`SELECT COUNT(*) FROM (SELECT MAX(users.id) FROM users) AS counted`,
+	)
+
+	// Errors are kept
+	expect.Error(CountOf(Select(users.C("what"))))
+	expect.Error(CountOf(Select(users.C("what")).Distinct()))
+}
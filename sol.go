@@ -14,7 +14,49 @@ type defaultDialect struct{}
 
 // The default dialect must implement the dialect.Dialect interface
 var _ dialect.Dialect = &defaultDialect{}
+var _ dialect.Locker = &defaultDialect{}
+var _ dialect.DistinctOner = &defaultDialect{}
+var _ dialect.InsertDefaulter = &defaultDialect{}
+var _ dialect.Commenter = &defaultDialect{}
+var _ dialect.Deferrer = &defaultDialect{}
+var _ dialect.ViewReplacer = &defaultDialect{}
+var _ dialect.Virtualizer = &defaultDialect{}
 
 func (dialect defaultDialect) Param(i int) string {
 	return fmt.Sprintf(`$%d`, i+1)
 }
+
+// Comments returns CommentOn since the default dialect outputs COMMENT ON
+func (d defaultDialect) Comments() dialect.CommentStyle {
+	return dialect.CommentOn
+}
+
+// DistinctOn returns true since the default dialect outputs DISTINCT ON
+func (dialect defaultDialect) DistinctOn() bool {
+	return true
+}
+
+// Deferrable returns true since the default dialect outputs DEFERRABLE
+func (dialect defaultDialect) Deferrable() bool {
+	return true
+}
+
+// InsertDefaults returns true since the default dialect outputs DEFAULT
+func (dialect defaultDialect) InsertDefaults() bool {
+	return true
+}
+
+// ReplaceViews returns true since the default dialect outputs OR REPLACE
+func (dialect defaultDialect) ReplaceViews() bool {
+	return true
+}
+
+// VirtualColumns returns true since the default dialect outputs VIRTUAL
+func (dialect defaultDialect) VirtualColumns() bool {
+	return true
+}
+
+// Locks returns true since the default dialect outputs every lock strength
+func (dialect defaultDialect) Locks(strength string) bool {
+	return true
+}
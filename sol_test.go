@@ -6,6 +6,13 @@ import (
 	"github.com/aodin/sol/types"
 )
 
+// plainDialect is a test dialect without any optional features
+type plainDialect struct{}
+
+func (d plainDialect) Param(i int) string {
+	return "?"
+}
+
 // Valid schemas should not panic
 var users = Table("users",
 	Column("id", types.Integer()),
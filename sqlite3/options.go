@@ -0,0 +1,15 @@
+package sqlite3
+
+import "github.com/aodin/sol"
+
+// WithoutRowID creates the table without the implicit rowid column. The
+// table must have a primary key.
+func WithoutRowID() sol.TableOption {
+	return sol.Option("WITHOUT ROWID")
+}
+
+// Strict enforces the types of the table's columns, which must be
+// INTEGER, REAL, TEXT, BLOB, or ANY. It requires sqlite 3.37.0 or later.
+func Strict() sol.TableOption {
+	return sol.Option("STRICT")
+}
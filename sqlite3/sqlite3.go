@@ -11,12 +11,46 @@ type Sqlite3 struct{}
 
 // The Sqlite3 dialect must implement the dialect.Dialect interface
 var _ dialect.Dialect = &Sqlite3{}
+var _ dialect.ParamLimiter = &Sqlite3{}
+var _ dialect.AutoIncrementer = &Sqlite3{}
+var _ dialect.ForwardReferencer = &Sqlite3{}
+var _ dialect.Deferrer = &Sqlite3{}
+var _ dialect.Virtualizer = &Sqlite3{}
 
 // Param returns the sqlite3 specific parameterization scheme.
 func (d *Sqlite3) Param(i int) string {
 	return `?`
 }
 
+// AutoIncrement returns an AUTOINCREMENT primary key, which must be
+// declared with its column
+func (d *Sqlite3) AutoIncrement() string {
+	return "INTEGER PRIMARY KEY AUTOINCREMENT"
+}
+
+// Deferrable returns true since sqlite3 foreign keys can be DEFERRABLE
+func (d *Sqlite3) Deferrable() bool {
+	return true
+}
+
+// ForwardReferences returns true since sqlite3 does not check that the
+// table of a foreign key exists until the key is used
+func (d *Sqlite3) ForwardReferences() bool {
+	return true
+}
+
+// VirtualColumns returns true since sqlite3 generated columns are
+// VIRTUAL by default
+func (d *Sqlite3) VirtualColumns() bool {
+	return true
+}
+
+// MaxParams returns the default SQLITE_MAX_VARIABLE_NUMBER of versions
+// prior to 3.32.0. Newer versions allow 32766.
+func (d *Sqlite3) MaxParams() int {
+	return 999
+}
+
 // Dialect is a constructor for the Sqlite3 Dialect
 func Dialect() *Sqlite3 {
 	return &Sqlite3{}
@@ -1,6 +1,8 @@
 package sqlite3
 
 import (
+	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
@@ -135,3 +137,272 @@ func TestSqlite3_Transaction(t *testing.T) {
 		panicTx.Rollback()
 	})
 }
+
+type setting struct {
+	Name  string
+	Value struct {
+		Enabled bool     `json:"enabled"`
+		Tags    []string `json:"tags"`
+	} `db:",json"`
+	Counts map[string]int
+}
+
+// TestSqlite3_JSON tests that JSON fields are encoded and decoded
+func TestSqlite3_JSON(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	settings := sol.Table("settings",
+		sol.Column("name", types.Varchar()),
+		sol.Column("value", types.Text()),
+		sol.Column("counts", types.Text()),
+	)
+	require.Nil(t, conn.Query(settings.Create()))
+
+	var a setting
+	a.Name = "a"
+	a.Value.Enabled = true
+	a.Value.Tags = []string{"x", "y"}
+	a.Counts = map[string]int{"views": 2}
+	require.Nil(t, conn.Query(settings.Insert().Values(a)))
+	require.Nil(t, conn.Query(settings.Insert().Values(setting{Name: "b"})))
+
+	var all []setting
+	require.Nil(t, conn.Query(settings.Select().OrderBy(settings.C("name")), &all))
+	require.Equal(t, 2, len(all))
+	assert.Equal(t, a, all[0])
+	assert.Equal(t, setting{Name: "b"}, all[1], "NULL should be decoded as zero")
+
+	var one setting
+	require.Nil(t, conn.Query(settings.Select().Limit(1), &one))
+	assert.Equal(t, a, one)
+}
+
+// TestSqlite3_Enum tests that enums are enforced with a CHECK constraint
+func TestSqlite3_Enum(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	tasks := sol.Table("tasks",
+		sol.Column("status", types.Enum("status", "todo", "done").NotNull()),
+	)
+	require.Nil(t, conn.Query(tasks.Create()))
+	require.Nil(t, conn.Query(tasks.Insert().Values(sol.Values{"status": "todo"})))
+
+	// Values that are not literals are checked by the database
+	assert.NotNil(t, conn.Query(tasks.Insert().Values(sol.Values{
+		"status": sql.NullString{String: "doing", Valid: true},
+	})))
+}
+
+func TestSqlite3_Check(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	price := sol.Column("price", types.Integer().NotNull())
+	items := sol.Table("items",
+		sol.Column("sku", types.Varchar().NotNull()),
+		price,
+		sol.Unique("sku").Named("items_sku_key"),
+		sol.Check("positive_price", price.GreaterThan(0)),
+	)
+	require.Nil(t, conn.Query(items.Create()))
+	require.Nil(t, conn.Query(items.Insert().Values(sol.Values{
+		"sku": "a", "price": 1,
+	})))
+
+	// The database enforces the CHECK constraint
+	assert.NotNil(t, conn.Query(items.Insert().Values(sol.Values{
+		"sku": "b", "price": 0,
+	})))
+}
+
+func TestSqlite3_Default(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	items := sol.Table("items",
+		sol.Column("name", types.Varchar().NotNull()),
+		sol.Column("status", types.Varchar().NotNull().Default("new")),
+		sol.Column("quantity", types.Integer().NotNull().Default(1)),
+	)
+	require.Nil(t, conn.Query(items.Create()))
+
+	type item struct {
+		Name     string
+		Status   string `db:",omitempty"`
+		Quantity int64  `db:",omitempty"`
+	}
+	require.Nil(t, conn.Query(items.Insert().Values(item{Name: "a"})))
+
+	var selected item
+	require.Nil(t, conn.Query(items.Select(), &selected))
+	assert.Equal(t, item{Name: "a", Status: "new", Quantity: 1}, selected)
+}
+
+func TestSqlite3_InsertID(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	items := sol.Table("items",
+		sol.Column("id", types.AutoIncrement()),
+		sol.Column("name", types.Varchar().NotNull()),
+	)
+	expect := sol.NewTester(t, &Sqlite3{})
+	expect.SQL(
+		items.Create(),
+		`CREATE TABLE items (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR NOT NULL
+);`,
+	)
+	require.Nil(t, conn.Query(items.Create()))
+
+	type item struct {
+		ID   int64
+		Name string
+	}
+	a := item{Name: "a"}
+	require.Nil(t, sol.InsertID(conn, items, &a))
+	assert.Equal(t, int64(1), a.ID)
+
+	many := []item{{Name: "b"}, {Name: "c"}}
+	require.Nil(t, sol.InsertID(conn, items, many))
+	assert.Equal(t, int64(2), many[0].ID)
+	assert.Equal(t, int64(3), many[1].ID)
+
+	var selected item
+	require.Nil(t, conn.Query(items.Select().Where(items.C("id").Equals(3)), &selected))
+	assert.Equal(t, many[1], selected)
+
+	assert.NotNil(t, sol.InsertID(conn, items, a), "structs must be pointers")
+}
+
+func TestSqlite3_Generated(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	price := sol.Column("price", types.Integer().NotNull())
+	quantity := sol.Column("quantity", types.Integer().NotNull())
+	items := sol.Table("items",
+		sol.Column("sku", types.Text().Collate("NOCASE").NotNull()),
+		price,
+		quantity,
+		sol.Column("total", sol.Generated(
+			types.Integer(),
+			sol.BinaryClause{Pre: price, Post: quantity, Sep: " * "},
+		).Virtual()),
+	)
+	require.Nil(t, conn.Query(items.Create()))
+
+	type item struct {
+		SKU      string `db:"sku"`
+		Price    int64
+		Quantity int64
+		Total    int64
+	}
+	require.Nil(t, conn.Query(items.Insert().Values(item{
+		SKU: "ABC", Price: 2, Quantity: 3,
+	})))
+
+	// The total is computed and the SKU compared without case
+	var selected item
+	require.Nil(t, conn.Query(
+		items.Select().Where(items.C("sku").Equals("abc")), &selected,
+	))
+	assert.Equal(t, int64(6), selected.Total)
+}
+
+func TestSqlite3_Options(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	// Comments are omitted since sqlite3 does not support them
+	tags := sol.Table("tags",
+		sol.Column("name", types.Text().NotNull()).Comment("Unique name"),
+		sol.Column("uses", types.Integer().NotNull()),
+		sol.PrimaryKey("name"),
+		sol.Comment("Item tags"),
+		WithoutRowID(),
+		Strict(), // Requires sqlite 3.37.0 or later
+	)
+	expect := sol.NewTester(t, &Sqlite3{})
+	expect.SQL(
+		tags.Create(),
+		`CREATE TABLE tags (
+  name TEXT NOT NULL,
+  uses INTEGER NOT NULL,
+  PRIMARY KEY (name)
+) WITHOUT ROWID, STRICT;`,
+	)
+
+	rowless := sol.Table("rowless",
+		sol.Column("name", types.Text().NotNull()),
+		sol.PrimaryKey("name"),
+		WithoutRowID(),
+	)
+	require.Nil(t, conn.Query(rowless.Create()))
+
+	// STRICT tables require sqlite 3.37 or later
+	var version string
+	require.Nil(t, conn.Query(sol.Text(`SELECT sqlite_version()`), &version))
+	var major, minor int
+	_, err = fmt.Sscanf(version, "%d.%d", &major, &minor)
+	require.Nil(t, err, "Failed to parse the sqlite version %s", version)
+	if major == 3 && minor < 37 {
+		t.Skipf("sqlite %s does not support STRICT tables", version)
+	}
+
+	require.Nil(t, conn.Query(tags.Create()))
+	require.Nil(t, conn.Query(tags.Insert().Values(sol.Values{"name": "a", "uses": 1})))
+
+	// Strict tables reject values of the wrong type
+	assert.NotNil(t, conn.Query(tags.Insert().Values(sol.Values{"name": "b", "uses": "many"})))
+}
+
+func TestSqlite3_MetaData(t *testing.T) {
+	conn, err := sol.Open("sqlite3", ":memory:")
+	require.Nil(t, err, `Failed to connect to in-memory sqlite3 instance`)
+	defer conn.Close()
+
+	departments := sol.Table("departments",
+		sol.Column("id", types.Integer()),
+		sol.Column("manager_id", types.Integer()),
+		sol.PrimaryKey("id"),
+	)
+	employees := sol.Table("employees",
+		sol.Column("id", types.Integer()),
+		sol.ForeignKey("department_id", departments),
+		sol.PrimaryKey("id"),
+	)
+	require.Nil(t, sol.ForeignKeyConstraint(
+		[]string{"manager_id"}, employees, nil,
+	).Modify(departments))
+
+	managers, err := sol.View("managers", sol.Select(employees.C("id")))
+	require.Nil(t, err)
+
+	// Tables that reference each other are created with their foreign keys
+	schema := sol.NewMetaData(employees, departments, managers)
+	require.Nil(t, schema.CreateAll(conn))
+	require.Nil(t, schema.CreateAll(conn), "tables and views should only be created if they do not exist")
+
+	require.Nil(t, conn.Query(departments.Insert().Values(sol.Values{"id": 1, "manager_id": 2})))
+	require.Nil(t, conn.Query(employees.Insert().Values(sol.Values{"id": 2, "department_id": 1})))
+
+	var ids []int64
+	require.Nil(t, conn.Query(sol.Text(`SELECT id FROM managers`), &ids))
+	assert.Equal(t, []int64{2}, ids)
+
+	require.Nil(t, schema.DropAll(conn))
+	require.Nil(t, schema.DropAll(conn), "tables should only be dropped if they exist")
+	assert.NotNil(t, conn.Query(employees.Select()), "tables should be dropped")
+	assert.NotNil(t, conn.Query(sol.Text(`SELECT id FROM managers`)), "views should be dropped")
+}
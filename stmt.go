@@ -5,16 +5,18 @@ import "fmt"
 // Stmt is the base of all statements, including SELECT, UPDATE, DELETE, and
 // INSERT statements
 type Stmt struct {
-	errs stmtErrors
+	errs CompileError
 }
 
 // AddMeta adds a meta errors to the Stmt errors
 func (stmt *Stmt) AddMeta(msg string, args ...interface{}) {
-	// Create errs if they don't exist
-	if stmt.errs.fields == nil {
-		stmt.errs = stmtErrors{fields: make(map[fieldError]string)}
-	}
-	stmt.errs.meta = append(stmt.errs.meta, fmt.Sprintf(msg, args...))
+	stmt.errs.add(FieldError{Msg: fmt.Sprintf(msg, args...)})
+}
+
+// AddError adds the given error to the Stmt errors. The structure of
+// FieldError and CompileError types will be kept.
+func (stmt *Stmt) AddError(err error) {
+	stmt.errs.add(err)
 }
 
 // Error returns the statement's inner error
@@ -25,8 +27,6 @@ func (stmt Stmt) Error() error {
 	return nil
 }
 
-// TODO error setter
-
 // ConditionalStmt includes SELECT, DELETE, and UPDATE statements
 type ConditionalStmt struct {
 	Stmt
@@ -31,10 +31,42 @@ type TableElem struct {
 	fks          []FKElem // This table's foreign keys
 	referencedBy []FKElem // Foreign keys that reference this table
 	creates      []types.Type
+	comment      string
+	options      []TableOption // Options of the CREATE TABLE statement
 }
 
 var _ Tabular = &TableElem{}
 
+// AddConstraint adds the given constraint to the table's CREATE TABLE
+// statement. It allows modifiers outside this package, such as dialect
+// specific constraints, to be created with the table.
+func (table *TableElem) AddConstraint(constraint types.Type) {
+	table.creates = append(table.creates, constraint)
+}
+
+// Alias returns the table's alias
+func (table TableElem) Alias() string {
+	return table.alias
+}
+
+// As returns a copy of the table with the given alias. Columns of the
+// copy will be prefixed with the alias, which allows a table to be
+// joined to itself.
+//  managers := Employees.As("managers")
+//  Employees.Select().InnerJoin(
+//      managers, Employees.C("manager_id").Equals(managers.C("id")),
+//  )
+func (table *TableElem) As(alias string) *TableElem {
+	aliased := *table
+	aliased.alias = alias
+	aliased.columns = UniqueColumns()
+	for _, column := range table.columns.All() {
+		column.table = &aliased
+		aliased.columns.order = append(aliased.columns.order, column)
+	}
+	return &aliased
+}
+
 // Column returns the column as a ColumnElem. If the column does not exist
 // it will return the ColumnElem in an invalid state that will be used to
 // construct an error message
@@ -58,7 +90,11 @@ func (table TableElem) Columns() []ColumnElem {
 	return table.columns.All()
 }
 
+// Compile outputs the table name, including its alias if one is set
 func (table TableElem) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
+	if table.alias != "" {
+		return fmt.Sprintf(`%s AS %s`, table.name, table.alias), nil
+	}
 	return table.Name(), nil
 }
 
@@ -99,6 +135,15 @@ func (table *TableElem) Name() string {
 	return fmt.Sprintf(`%s`, table.name)
 }
 
+// reference returns the name that columns of the table are prefixed with:
+// the alias if one is set, otherwise the table name
+func (table *TableElem) reference() string {
+	if table.alias != "" {
+		return table.alias
+	}
+	return table.name
+}
+
 // PrimaryKey returns the primary key array
 func (table TableElem) PrimaryKey() PKArray {
 	return table.pk
@@ -125,6 +170,15 @@ func (table *TableElem) Update() UpdateStmt {
 	return Update(table)
 }
 
+// tableReference returns the name that columns of the given tabular
+// are prefixed with
+func tableReference(tabular Tabular) string {
+	if table, ok := tabular.(*TableElem); ok {
+		return table.reference()
+	}
+	return tabular.Name()
+}
+
 // Table creates a new dialect netural table. It will panic on any errors.
 func Table(name string, modifiers ...Modifier) *TableElem {
 	if err := isValidTableName(name); err != nil {
@@ -1,6 +1,10 @@
 package sol
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/aodin/sol/types"
+)
 
 // All schemas are declared in sol_test.go
 
@@ -30,6 +34,21 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTable_autoIncrement(t *testing.T) {
+	items := Table("items",
+		Column("id", types.AutoIncrement()),
+		Column("name", types.Varchar()),
+	)
+	if pk := items.PrimaryKey(); len(pk) != 1 || pk[0] != "id" {
+		t.Errorf("AutoIncrement columns should be the primary key: %v", pk)
+	}
+
+	// A table can only have one primary key
+	if err := PrimaryKey("name").Modify(items); err == nil {
+		t.Errorf("A second primary key should error")
+	}
+}
+
 func TestTable_Select(t *testing.T) {
 	expect := NewTester(t, defaultDialect{})
 
@@ -39,3 +58,28 @@ func TestTable_Select(t *testing.T) {
 		`SELECT users.id, users.email, users.name, users.password, users.created_at FROM users`,
 	)
 }
+
+func TestTable_As(t *testing.T) {
+	expect := NewTester(t, defaultDialect{})
+
+	u2 := users.As("u2")
+	if users.Alias() != "" || u2.Alias() != "u2" {
+		t.Errorf("As should not modify the original table")
+	}
+	if u2.Name() != "users" {
+		t.Errorf("Unexpected aliased table name: %s != users", u2.Name())
+	}
+
+	expect.SQL(
+		u2.Select().Where(u2.C("id").Equals(1)),
+		`SELECT u2.id, u2.email, u2.name, u2.password, u2.created_at FROM users AS u2 WHERE u2.id = $1`,
+		1,
+	)
+
+	// An aliased table and its original are separate tables in FROM
+	expect.SQL(
+		Select(users.C("id"), u2.C("id")),
+		`SELECT users.id, u2.id FROM users, users AS u2`,
+	)
+	expect.Error(u2.Select(u2.C("missing")))
+}
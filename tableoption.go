@@ -0,0 +1,63 @@
+package sol
+
+import "fmt"
+
+// TableComment is a Modifier that gives a table a comment. Comments are
+// created by dialects that support them and otherwise omitted.
+type TableComment string
+
+var _ Modifier = TableComment("")
+
+// Modify implements the Modifier interface
+func (comment TableComment) Modify(tabular Tabular) error {
+	if tabular == nil || tabular.Table() == nil {
+		return fmt.Errorf("sol: comments cannot modify a nil table")
+	}
+	tabular.Table().comment = string(comment)
+	return nil
+}
+
+// Comment creates a TableComment with the given text
+//  users := sol.Table("users",
+//      sol.Column("id", types.Integer()),
+//      sol.Comment("Registered users"),
+//  )
+func Comment(text string) TableComment {
+	return TableComment(text)
+}
+
+// TableOption is a Modifier that adds a dialect specific option to the
+// CREATE TABLE statement of a table. The dialect packages provide common
+// options, such as mysql.Engine or sqlite3.WithoutRowID.
+type TableOption struct {
+	option      string
+	isQualifier bool
+}
+
+var _ Modifier = TableOption{}
+
+// Modify implements the Modifier interface
+func (option TableOption) Modify(tabular Tabular) error {
+	if tabular == nil || tabular.Table() == nil {
+		return fmt.Errorf("sol: table options cannot modify a nil table")
+	}
+	if option.option == "" {
+		return fmt.Errorf("sol: table options cannot be blank")
+	}
+	table := tabular.Table() // Get the dialect neutral table
+	table.options = append(table.options, option)
+	return nil
+}
+
+// Option creates a TableOption that follows the column list of the
+// CREATE TABLE statement, such as ENGINE=InnoDB. Options are separated
+// by commas.
+func Option(option string) TableOption {
+	return TableOption{option: option}
+}
+
+// Qualifier creates a TableOption that precedes TABLE in the CREATE TABLE
+// statement, such as the UNLOGGED of CREATE UNLOGGED TABLE
+func Qualifier(qualifier string) TableOption {
+	return TableOption{option: qualifier, isQualifier: true}
+}
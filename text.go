@@ -1,6 +1,7 @@
 package sol
 
 import (
+	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
@@ -58,7 +59,7 @@ func (stmt TextStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error)
 		param := &Parameter{Value: value}
 		replacement, err := param.Compile(d, ps)
 		if err != nil {
-			stmt.AddMeta(err.Error())
+			stmt.AddError(err)
 		}
 		return replacement
 	}
@@ -86,7 +87,7 @@ func (stmt TextStmt) Values(obj interface{}) TextStmt {
 	case reflect.Struct:
 		var err error
 		if stmt.values, err = ValuesOf(obj); err != nil {
-			stmt.AddMeta(err.Error())
+			stmt.AddError(err)
 			return stmt
 		}
 	default:
@@ -94,10 +95,13 @@ func (stmt TextStmt) Values(obj interface{}) TextStmt {
 	}
 
 	if unsupported {
-		stmt.AddMeta(
-			"sol: unsupported type %T for inserted values - accepted types: struct types or Values",
-			obj,
-		)
+		stmt.AddError(FieldError{
+			Kind: UnsupportedValue,
+			Msg: fmt.Sprintf(
+				"sol: unsupported type %T for inserted values - accepted types: struct types or Values",
+				obj,
+			),
+		})
 	}
 	return stmt
 }
@@ -7,8 +7,13 @@ const (
 	CROSSJOIN      = "CROSS JOIN"
 	DATE           = "DATE"
 	DATEPART       = "DATE_PART"
+	DEFAULT        = "DEFAULT"
 	DELETE         = "DELETE"
 	DISTINCT       = "DISTINCT"
+	DISTINCTON     = "DISTINCT ON"
+	FORNOKEYUPDATE = "FOR NO KEY UPDATE"
+	FORSHARE       = "FOR SHARE"
+	FORUPDATE      = "FOR UPDATE"
 	FROM           = "FROM"
 	FULLOUTERJOIN  = "FULL OUTER JOIN"
 	GROUPBY        = "GROUP BY"
@@ -20,11 +25,15 @@ const (
 	LIMIT          = "LIMIT"
 	MAX            = "MAX"
 	MIN            = "MIN"
+	NOWAIT         = "NOWAIT"
+	OF             = "OF"
 	OFFSET         = "OFFSET"
 	ORDERBY        = "ORDER BY"
 	RIGHTOUTERJOIN = "RIGHT OUTER JOIN"
 	SELECT         = "SELECT"
+	RETURNING      = "RETURNING"
 	SET            = "SET"
+	SKIPLOCKED     = "SKIP LOCKED"
 	STDDEV         = "STDDEV"
 	SUM            = "SUM"
 	UPDATE         = "UPDATE"
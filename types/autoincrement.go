@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// AutoIncrementer is implemented by types whose values are generated by
+// the database when they are omitted from an INSERT
+type AutoIncrementer interface {
+	Defaulter
+	AutoIncrements() bool
+}
+
+// PrimaryKeyer is implemented by types that declare their column as the
+// primary key of its table
+type PrimaryKeyer interface {
+	IsPrimaryKey() bool
+}
+
+type autoIncrement struct{}
+
+var _ AutoIncrementer = autoIncrement{}
+var _ PrimaryKeyer = autoIncrement{}
+
+// Create returns the dialect's auto-incrementing primary key
+func (t autoIncrement) Create(d dialect.Dialect) (string, error) {
+	compiled, ok := dialect.AutoIncrement(d)
+	if !ok {
+		return "", fmt.Errorf("types: the dialect does not support auto-increment")
+	}
+	return compiled, nil
+}
+
+// AutoIncrements returns true since values are generated by the database
+func (t autoIncrement) AutoIncrements() bool {
+	return true
+}
+
+// HasDefault returns true since omitted values will be generated
+func (t autoIncrement) HasDefault() bool {
+	return true
+}
+
+// IsPrimaryKey returns true since the column is always the primary key
+func (t autoIncrement) IsPrimaryKey() bool {
+	return true
+}
+
+// AutoIncrement creates an integer primary key whose values are generated
+// by the database. Since some dialects, such as sqlite3, must declare the
+// key with its column, it cannot be used with PrimaryKey.
+//  sol.Column("id", types.AutoIncrement())
+func AutoIncrement() autoIncrement {
+	return autoIncrement{}
+}
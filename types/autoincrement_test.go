@@ -0,0 +1,27 @@
+package types
+
+import "testing"
+
+// incrementDialect is a test dialect with auto-increment primary keys
+type incrementDialect struct{}
+
+func (d incrementDialect) Param(i int) string { return "?" }
+
+func (d incrementDialect) AutoIncrement() string { return "INTEGER AUTO" }
+
+func TestAutoIncrement(t *testing.T) {
+	datatype := AutoIncrement()
+	if _, err := datatype.Create(nil); err == nil {
+		t.Errorf("AutoIncrement without dialect support should error")
+	}
+	create, err := datatype.Create(incrementDialect{})
+	if err != nil {
+		t.Errorf("Unexpected error during AutoIncrement Create(): %s", err)
+	}
+	if create != "INTEGER AUTO" {
+		t.Errorf("Unexpected output of AutoIncrement type: %s", create)
+	}
+	if !datatype.HasDefault() || !datatype.IsPrimaryKey() {
+		t.Errorf("AutoIncrement should be a primary key with a default")
+	}
+}
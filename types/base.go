@@ -6,27 +6,66 @@ import (
 	"github.com/aodin/sol/dialect"
 )
 
+// NotNuller is an optional interface for types that report whether
+// their columns are NOT NULL
+type NotNuller interface {
+	IsNotNull() bool
+}
+
 // BaseType is foundational datatype that includes fields that nearly all
 // datatypes implement
 type BaseType struct {
-	name      string
-	isNotNull bool
-	isUnique  bool
+	name         string
+	isNotNull    bool
+	isUnique     bool
+	hasDefault   bool
+	defaultValue interface{}
 }
 
 var _ Type = BaseType{}
+var _ Defaulter = BaseType{}
+var _ NotNuller = BaseType{}
 
 // Create generates the
 func (base BaseType) Create(d dialect.Dialect) (string, error) {
-	clauses := append([]string{base.name}, base.Options()...)
+	return base.create(d, base.name)
+}
+
+// create outputs the given name of the type followed by its options
+func (base BaseType) create(d dialect.Dialect, name string) (string, error) {
+	clauses := append([]string{name}, base.Options()...)
+	if base.hasDefault {
+		compiled, err := compileDefault(d, base.defaultValue)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, compiled)
+	}
 	return strings.Join(clauses, " "), nil
 }
 
+// Default sets the DEFAULT of the BaseType, which can be a literal value,
+// an Expression, or a Sequence
+func (base *BaseType) Default(value interface{}) {
+	base.hasDefault = true
+	base.defaultValue = value
+}
+
+// HasDefault returns true if the BaseType has a DEFAULT
+func (base BaseType) HasDefault() bool {
+	return base.hasDefault
+}
+
 // Unique sets the BaseType to UNIQUE
 func (base *BaseType) Unique() {
 	base.isUnique = true
 }
 
+// IsNotNull returns true if the BaseType is NOT NULL
+func (base BaseType) IsNotNull() bool {
+	return base.isNotNull
+}
+
 // NotNull sets the BaseType to NOT NULL
 func (base *BaseType) NotNull() {
 	base.isNotNull = true
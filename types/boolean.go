@@ -1,12 +1,5 @@
 package types
 
-import (
-	"fmt"
-	"strings"
-
-	"github.com/aodin/sol/dialect"
-)
-
 // Provide a nullable boolean for Boolean type default values
 var (
 	internalTrue  bool = true
@@ -17,29 +10,13 @@ var (
 
 type boolean struct {
 	BaseType
-	value *bool
 }
 
 var _ Type = boolean{}
 
-func (t boolean) Create(d dialect.Dialect) (string, error) {
-	compiled, err := t.BaseType.Create(d)
-	if err != nil {
-		return "", err
-	}
-	if t.value != nil {
-		compiled += strings.ToUpper(fmt.Sprintf(" DEFAULT %t", *t.value))
-	}
-	return compiled, nil
-}
-
 // Default will set the default value of the boolean
 func (t boolean) Default(value bool) boolean {
-	if value {
-		t.value = True
-	} else {
-		t.value = False
-	}
+	t.BaseType.Default(value)
 	return t
 }
 
@@ -2,14 +2,14 @@ package types
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/aodin/sol/dialect"
 )
 
 type character struct {
 	BaseType
-	limit int
+	limit     int
+	collation string
 }
 
 func (t character) Create(d dialect.Dialect) (string, error) {
@@ -17,8 +17,22 @@ func (t character) Create(d dialect.Dialect) (string, error) {
 	if t.limit != 0 {
 		name += fmt.Sprintf("(%d)", t.limit)
 	}
-	compiled := append([]string{name}, t.BaseType.Options()...)
-	return strings.Join(compiled, " "), nil
+	if t.collation != "" {
+		name += fmt.Sprintf(" COLLATE %s", dialect.Collation(d, t.collation))
+	}
+	return t.BaseType.create(d, name)
+}
+
+// Collate sets the collation of the column, such as NOCASE in sqlite3,
+// utf8mb4_bin in MySQL, or "C" in postgres
+func (t character) Collate(collation string) character {
+	t.collation = collation
+	return t
+}
+
+func (t character) Default(value interface{}) character {
+	t.BaseType.Default(value)
+	return t
 }
 
 func (t character) Limit(n int) character {
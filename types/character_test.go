@@ -14,3 +14,14 @@ func TestCharacter(t *testing.T) {
 		t.Errorf("Unexpected output of VARCHAR type: %s", create)
 	}
 }
+
+func TestCharacter_Collate(t *testing.T) {
+	datatype := Text().Collate("NOCASE").NotNull()
+	create, err := datatype.Create(nil)
+	if err != nil {
+		t.Errorf("Unexpected error during TEXT Create() with COLLATE: %s", err)
+	}
+	if create != "TEXT COLLATE NOCASE NOT NULL" {
+		t.Errorf("Unexpected output of TEXT type with COLLATE: %s", create)
+	}
+}
@@ -6,6 +6,11 @@ type datetime struct {
 
 var _ Type = datetime{}
 
+func (t datetime) Default(value interface{}) datetime {
+	t.BaseType.Default(value)
+	return t
+}
+
 func (t datetime) NotNull() datetime {
 	t.BaseType.NotNull()
 	return t
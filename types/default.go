@@ -0,0 +1,84 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// Defaulter is implemented by types that may have a server-side default,
+// which allows their columns to be omitted from INSERT statements
+type Defaulter interface {
+	HasDefault() bool
+}
+
+// expression is a SQL expression used as a default value
+type expression string
+
+// Expression creates a default value from the given SQL expression,
+// which will be output as is
+//  types.Timestamp().Default(types.Expression("CURRENT_TIMESTAMP"))
+func Expression(sql string) expression {
+	return expression(sql)
+}
+
+// sequence is the name of a sequence used as a default value
+type sequence string
+
+// Sequence creates a default value from the next value of the given
+// sequence. Only dialects with sequences, such as postgres, support it.
+func Sequence(name string) sequence {
+	return sequence(name)
+}
+
+// compileDefault returns the DEFAULT option of the given value
+func compileDefault(d dialect.Dialect, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case expression:
+		return fmt.Sprintf("DEFAULT (%s)", v), nil
+	case sequence:
+		nextval, ok := dialect.NextVal(d, string(v))
+		if !ok {
+			return "", fmt.Errorf(
+				"types: the dialect does not support the sequence %s", v,
+			)
+		}
+		return fmt.Sprintf("DEFAULT %s", nextval), nil
+	}
+	compiled, err := Literal(d, value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("DEFAULT %s", compiled), nil
+}
+
+// Literal returns the given value as a SQL literal of the dialect.
+// Strings are quoted and escaped by the dialect.
+func Literal(d dialect.Dialect, value interface{}) (string, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		var err error
+		if value, err = valuer.Value(); err != nil {
+			return "", err
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return dialect.Quote(d, v), nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32, float64:
+		return fmt.Sprintf("%v", v), nil
+	case time.Time:
+		return dialect.Quote(d, v.Format("2006-01-02 15:04:05.999999999-07:00")), nil
+	}
+	return "", fmt.Errorf("types: %T cannot be used as a literal value", value)
+}
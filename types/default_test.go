@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// sequenceDialect is a test dialect with sequences and its own quoting
+type sequenceDialect struct{}
+
+func (d sequenceDialect) Param(i int) string { return "?" }
+
+func (d sequenceDialect) NextVal(name string) string {
+	return "nextval('" + name + "')"
+}
+
+func (d sequenceDialect) Quote(s string) string { return `"` + s + `"` }
+
+func TestDefault(t *testing.T) {
+	jan1 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	for want, datatype := range map[string]Type{
+		"INTEGER NOT NULL DEFAULT 0":               Integer().NotNull().Default(0),
+		"VARCHAR(8) DEFAULT 'it''s'":               Varchar(8).Default("it's"),
+		"TEXT DEFAULT NULL":                        Text().Default(nil),
+		"BOOLEAN DEFAULT FALSE":                    Boolean().Default(false),
+		"TIMESTAMP DEFAULT (CURRENT_TIMESTAMP)":    Timestamp().Default(Expression("CURRENT_TIMESTAMP")),
+		"DATE DEFAULT '2017-01-01 00:00:00+00:00'": Date().Default(jan1),
+		"VARCHAR(5) DEFAULT 'todo'":                Enum("status", "todo", "doing").Default("todo"),
+	} {
+		create, err := datatype.Create(nil)
+		if err != nil {
+			t.Errorf("Unexpected error during Create() with DEFAULT: %s", err)
+		}
+		if create != want {
+			t.Errorf("Unexpected DEFAULT output: %s != %s", create, want)
+		}
+	}
+
+	// Sequences and quoting are dialect dependent
+	datatype := BigInt().Default(Sequence("items_id_seq"))
+	if _, err := datatype.Create(nil); err == nil {
+		t.Errorf("A DEFAULT sequence without dialect support should error")
+	}
+	create, err := datatype.Create(sequenceDialect{})
+	if err != nil {
+		t.Errorf("Unexpected error during Create() with a DEFAULT sequence: %s", err)
+	}
+	if create != "BIGINT DEFAULT nextval('items_id_seq')" {
+		t.Errorf("Unexpected DEFAULT sequence output: %s", create)
+	}
+	if literal, _ := Literal(sequenceDialect{}, "a"); literal != `"a"` {
+		t.Errorf("Literal strings should be quoted by the dialect: %s", literal)
+	}
+	if _, err := Literal(nil, []int{1}); err == nil {
+		t.Errorf("Literal of an unsupported type should error")
+	}
+
+	if !Integer().Default(0).HasDefault() || Integer().HasDefault() {
+		t.Errorf("HasDefault should be true only if a DEFAULT was set")
+	}
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// Enumerated is implemented by types with a fixed set of allowed values
+type Enumerated interface {
+	Type
+	Name() string
+	Values() []string
+	Has(string) bool
+	Check(d dialect.Dialect, column string) string
+	CreateType(d dialect.Dialect, ifNotExists bool) string
+}
+
+type enum struct {
+	BaseType
+	values []string
+}
+
+var _ Enumerated = enum{}
+
+// Create returns the type of the enum's column, which depends on how the
+// dialect creates enums: the enum's name if it is created as a type,
+// an inline ENUM(...), or otherwise a VARCHAR long enough for every value.
+func (t enum) Create(d dialect.Dialect) (string, error) {
+	if len(t.values) == 0 {
+		return "", fmt.Errorf("types: enum %s has no values", t.name)
+	}
+	var name string
+	switch dialect.Enums(d) {
+	case dialect.NamedEnum:
+		name = t.name
+	case dialect.InlineEnum:
+		name = fmt.Sprintf("ENUM(%s)", t.quoted(d))
+	default:
+		var length int
+		for _, value := range t.values {
+			if len(value) > length {
+				length = len(value)
+			}
+		}
+		name = fmt.Sprintf("VARCHAR(%d)", length)
+	}
+	return t.BaseType.create(d, name)
+}
+
+// Check returns a CHECK constraint that limits the given column to the
+// values of the enum
+func (t enum) Check(d dialect.Dialect, column string) string {
+	return fmt.Sprintf("CHECK (%s IN (%s))", column, t.quoted(d))
+}
+
+// CreateType returns the CREATE TYPE statement for dialects that create
+// enums as types. Since postgres does not support CREATE TYPE IF NOT
+// EXISTS, duplicate types will be ignored by a DO block instead.
+func (t enum) CreateType(d dialect.Dialect, ifNotExists bool) string {
+	create := fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", t.name, t.quoted(d))
+	if ifNotExists {
+		return fmt.Sprintf(
+			"DO $$ BEGIN %s; EXCEPTION WHEN duplicate_object THEN NULL; END $$",
+			create,
+		)
+	}
+	return create
+}
+
+// Has returns true if the given value is allowed by the enum
+func (t enum) Has(value string) bool {
+	for _, allowed := range t.values {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Default sets the DEFAULT of the enum, which should be one of its values
+func (t enum) Default(value interface{}) enum {
+	t.BaseType.Default(value)
+	return t
+}
+
+// Name returns the name of the enum's type
+func (t enum) Name() string {
+	return t.name
+}
+
+func (t enum) NotNull() enum {
+	t.BaseType.NotNull()
+	return t
+}
+
+func (t enum) Unique() enum {
+	t.BaseType.Unique()
+	return t
+}
+
+// Values returns the allowed values of the enum in order
+func (t enum) Values() []string {
+	return t.values
+}
+
+// quoted returns the values as a list of string literals of the dialect
+func (t enum) quoted(d dialect.Dialect) string {
+	quoted := make([]string, len(t.values))
+	for i, value := range t.values {
+		quoted[i] = dialect.Quote(d, value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Enum creates an enumerated type with the given name and allowed values.
+// The name is only used by dialects that create enums as types.
+//  sol.Column("status", types.Enum("status", "active", "inactive"))
+func Enum(name string, values ...string) (t enum) {
+	t.name = name
+	t.values = values
+	return
+}
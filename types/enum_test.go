@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/aodin/sol/dialect"
+)
+
+// enumDialect is a test dialect with the given enum style
+type enumDialect dialect.EnumStyle
+
+func (d enumDialect) Param(i int) string { return "?" }
+
+func (d enumDialect) Enums() dialect.EnumStyle { return dialect.EnumStyle(d) }
+
+func TestEnum(t *testing.T) {
+	datatype := Enum("status", "active", "on hold", "it's done").NotNull()
+
+	for d, want := range map[dialect.Dialect]string{
+		nil:                             "VARCHAR(9) NOT NULL",
+		enumDialect(dialect.InlineEnum): "ENUM('active', 'on hold', 'it''s done') NOT NULL",
+		enumDialect(dialect.NamedEnum):  "status NOT NULL",
+	} {
+		create, err := datatype.Create(d)
+		if err != nil {
+			t.Errorf("Unexpected error during ENUM Create(): %s", err)
+		}
+		if create != want {
+			t.Errorf("Unexpected output of ENUM type: %s != %s", create, want)
+		}
+	}
+
+	if check := datatype.Check(nil, "state"); check != `CHECK (state IN ('active', 'on hold', 'it''s done'))` {
+		t.Errorf("Unexpected CHECK of ENUM type: %s", check)
+	}
+	if create := datatype.CreateType(nil, false); create != `CREATE TYPE status AS ENUM ('active', 'on hold', 'it''s done')` {
+		t.Errorf("Unexpected CREATE TYPE of ENUM type: %s", create)
+	}
+	if !datatype.Has("active") || datatype.Has("Active") {
+		t.Errorf("ENUM Has() should match values exactly")
+	}
+
+	if _, err := Enum("empty").Create(nil); err == nil {
+		t.Errorf("ENUM without values should error")
+	}
+}
@@ -8,6 +8,11 @@ type numeric struct {
 
 var _ Type = numeric{}
 
+func (t numeric) Default(value interface{}) numeric {
+	t.BaseType.Default(value)
+	return t
+}
+
 func (t numeric) NotNull() numeric {
 	t.BaseType.NotNull()
 	return t
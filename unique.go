@@ -54,6 +54,11 @@ func (unique UniqueArray) Modify(tabular Tabular) error {
 	return nil
 }
 
+// Named returns the unique constraint as a named constraint
+func (unique UniqueArray) Named(name string) NamedConstraint {
+	return NamedConstraint{name: name, constraint: unique}
+}
+
 func Unique(names ...string) UniqueArray {
 	return UniqueArray(names)
 }
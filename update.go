@@ -31,7 +31,9 @@ func (stmt UpdateStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if stmt.values == nil {
 		stmt.values = Values{}
 		for _, column := range stmt.table.Columns() {
-			stmt.values[column.Name()] = nil
+			if !isGenerated(column) {
+				stmt.values[column.Name()] = nil
+			}
 		}
 	}
 
@@ -40,6 +42,13 @@ func (stmt UpdateStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 		return "", fmt.Errorf("sol: UPDATE has no values")
 	}
 
+	// Literal values of enumerated columns must be allowed
+	for _, key := range stmt.values.Keys() {
+		if err := invalidEnum(stmt.table.C(key), stmt.values[key]); err != nil {
+			return "", err
+		}
+	}
+
 	// Compile the values
 	compiledValues, err := stmt.values.Compile(d, ps)
 	if err != nil {
@@ -53,7 +62,7 @@ func (stmt UpdateStmt) Compile(d dialect.Dialect, ps *Parameters) (string, error
 	if stmt.where != nil {
 		cc, err := stmt.where.Compile(d, ps)
 		if err != nil {
-			return "", err
+			return "", inClause(err, WHERE)
 		}
 		compiled = append(compiled, WHERE, cc)
 	}
@@ -72,12 +81,21 @@ func (stmt UpdateStmt) Values(values Values) UpdateStmt {
 
 	// Confirm that all values' keys are columns in the table
 	// TODO perform column alias matching? e.g. UUID > uuid or ItemID > item_id
+	if err := generatedValues(stmt.table, values, UPDATE); err != nil {
+		stmt.AddError(err)
+	}
 	for key := range values {
 		if !stmt.table.Has(key) {
-			stmt.AddMeta(
-				"sol: no column '%s' exists in the table '%s'",
-				key, stmt.table.Name(),
-			)
+			stmt.AddError(FieldError{
+				Kind:   UnknownColumn,
+				Column: key,
+				Table:  stmt.table.Name(),
+				Clause: UPDATE,
+				Msg: fmt.Sprintf(
+					"sol: no column '%s' exists in the table '%s'",
+					key, stmt.table.Name(),
+				),
+			})
 		}
 	}
 
@@ -22,7 +22,11 @@ func (v Values) Compile(d dialect.Dialect, ps *Parameters) (string, error) {
 	keys := v.Keys()
 	values := make([]string, len(keys))
 	for i, key := range keys {
-		param := NewParam(v[key])
+		// Clauses, such as functions of columns, are compiled as is
+		param, ok := v[key].(Clause)
+		if !ok {
+			param = NewParam(v[key])
+		}
 		compiledParam, err := param.Compile(d, ps)
 		if err != nil {
 			return "", err
@@ -159,9 +163,12 @@ func ValuesOf(obj interface{}) (Values, error) {
 		case *map[string]interface{}:
 			return Values(*converted), nil
 		default:
-			return nil, fmt.Errorf(
-				"sol: unsupported map type %T for ValuesOf()", converted,
-			)
+			return nil, FieldError{
+				Kind: UnsupportedValue,
+				Msg: fmt.Sprintf(
+					"sol: unsupported map type %T for ValuesOf()", converted,
+				),
+			}
 		}
 	case reflect.Struct:
 		values := Values{}
@@ -169,11 +176,14 @@ func ValuesOf(obj interface{}) (Values, error) {
 			if field.IsOmittable() {
 				continue // Skip zero values
 			}
-			values[field.Name] = field.Value.Interface()
+			values[field.Name] = field.Interface()
 		}
 		return values, nil
 	}
-	return nil, fmt.Errorf("sol: unsupported type %T for ValuesOf()", obj)
+	return nil, FieldError{
+		Kind: UnsupportedValue,
+		Msg:  fmt.Sprintf("sol: unsupported type %T for ValuesOf()", obj),
+	}
 }
 
 // isEmptyValue is from Go's encoding/json package: encode.go
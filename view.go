@@ -42,6 +42,7 @@ type CreateViewStmt struct {
 	view        ViewElem
 	isTemporary bool
 	orReplace   bool
+	ifNotExists bool
 }
 
 // String outputs the parameter-less CREATE View statement in a neutral
@@ -61,6 +62,13 @@ func (stmt CreateViewStmt) OrReplace() CreateViewStmt {
 	return stmt
 }
 
+// IfNotExists creates the view only if it does not exist. It is supported
+// by sqlite3, while postgres and MySQL should use OrReplace instead.
+func (stmt CreateViewStmt) IfNotExists() CreateViewStmt {
+	stmt.ifNotExists = true
+	return stmt
+}
+
 // Compile outputs the CREATE VIEW statement using the given dialect and
 // parameters. An error may be returned because of a pre-existing error or
 // because an error occurred during compilation.
@@ -73,6 +81,9 @@ func (stmt CreateViewStmt) Compile(d dialect.Dialect, p *Parameters) (string, er
 		name = "TEMPORARY"
 	}
 	name += " VIEW"
+	if stmt.ifNotExists {
+		name += " IF NOT EXISTS"
+	}
 
 	// TODO column aliases
 
@@ -81,7 +92,8 @@ func (stmt CreateViewStmt) Compile(d dialect.Dialect, p *Parameters) (string, er
 		return "", err
 	}
 
+	// The SELECT is not parenthesized, which sqlite3 does not allow
 	return fmt.Sprintf(
-		"%s %s AS (%s)", name, stmt.view.Name(), selectStmt,
+		"%s %s AS %s", name, stmt.view.Name(), selectStmt,
 	), nil
 }
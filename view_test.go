@@ -17,7 +17,7 @@ func TestView(t *testing.T) {
 
 	expect.SQL(
 		view.Create(),
-		`CREATE VIEW user_emails AS (SELECT users.id, users.email, users.name, users.password, users.created_at FROM users ORDER BY users.email)`,
+		`CREATE VIEW user_emails AS SELECT users.id, users.email, users.name, users.password, users.created_at FROM users ORDER BY users.email`,
 	)
 
 	expect.SQL(